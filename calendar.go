@@ -15,19 +15,29 @@ type Calendar struct {
 	parser              *parser
 	Version             float64
 	Timezone            *time.Location
+	Component           *Component
 	Events              Events
 	EventsByDate        map[string][]Index
 	EventsByID          map[string]Index
 	EventsByImportedID  map[string]Index
+	OverridesByUID      map[string][]Index
 	RecurringEvents     []Index
-	RecurringEventRules RRules
+	RecurringEventRules RRuleSets
+	// RecurringEventsByRef holds the position in RecurringEvents of every recurring event
+	RecurringEventsByRef map[Index]int
+	Todos                Todos
+	TodosByDueDate       map[string][]Index
+	TodosByID            map[string]Index
+	TodosByImportedID    map[string]Index
+	RecurringTodos       []Index
+	RecurringTodoRules   RRuleSets
 }
 
 type Index int
 
 // Events is an array of Event
 type Events []*Event
-type RRules []*rrule.RRule
+type RRuleSets []*rrule.Set
 
 func (events Events) Len() int {
 	return len(events)
@@ -51,8 +61,16 @@ func newCalendar(name string) *Calendar {
 	c.EventsByDate = make(map[string][]Index)
 	c.EventsByID = make(map[string]Index)
 	c.EventsByImportedID = make(map[string]Index)
+	c.OverridesByUID = make(map[string][]Index)
 	c.RecurringEvents = make([]Index, 0, 8)
-	c.RecurringEventRules = make([]*rrule.RRule, 0, 8)
+	c.RecurringEventRules = make([]*rrule.Set, 0, 8)
+	c.RecurringEventsByRef = make(map[Index]int)
+	c.Todos = make([]*Todo, 0, 8)
+	c.TodosByDueDate = make(map[string][]Index)
+	c.TodosByID = make(map[string]Index)
+	c.TodosByImportedID = make(map[string]Index)
+	c.RecurringTodos = make([]Index, 0, 8)
+	c.RecurringTodoRules = make([]*rrule.Set, 0, 8)
 	return c
 }
 
@@ -72,6 +90,31 @@ func NewFileCalendar(name string, filepath string) *Calendar {
 	return c
 }
 
+// SetParseOptions sets the options that are used when the calendar is loaded
+func (c *Calendar) SetParseOptions(options ParseOptions) {
+	if c.parser == nil {
+		c.parser = createParser(c.reader)
+	}
+	c.parser.setOptions(options)
+}
+
+// GetParseOptions returns the options that are used when the calendar is loaded
+func (c *Calendar) GetParseOptions() ParseOptions {
+	if c.parser == nil {
+		return DefaultParseOptions()
+	}
+	return c.parser.options()
+}
+
+// GetLocations returns the locations compiled from the VTIMEZONE components of the calendar,
+// they resolve the TZIDs of the calendar when parsing rules with rrule.StrToRRuleSetInLocations
+func (c *Calendar) GetLocations() rrule.Locations {
+	if c.parser == nil {
+		return rrule.Locations{}
+	}
+	return rrule.Locations(c.parser.locations)
+}
+
 func (c *Calendar) Load() error {
 	calendar := newCalendar(c.Name)
 	calendar.parser = c.parser
@@ -85,12 +128,21 @@ func (c *Calendar) Load() error {
 		c.parser = calendar.parser
 		c.Version = calendar.Version
 		c.Timezone = calendar.Timezone
+		c.Component = calendar.Component
 		c.Events = calendar.Events
 		c.EventsByDate = calendar.EventsByDate
 		c.EventsByID = calendar.EventsByID
 		c.EventsByImportedID = calendar.EventsByImportedID
+		c.OverridesByUID = calendar.OverridesByUID
 		c.RecurringEvents = calendar.RecurringEvents
 		c.RecurringEventRules = calendar.RecurringEventRules
+		c.RecurringEventsByRef = calendar.RecurringEventsByRef
+		c.Todos = calendar.Todos
+		c.TodosByDueDate = calendar.TodosByDueDate
+		c.TodosByID = calendar.TodosByID
+		c.TodosByImportedID = calendar.TodosByImportedID
+		c.RecurringTodos = calendar.RecurringTodos
+		c.RecurringTodoRules = calendar.RecurringTodoRules
 	}
 
 	return err
@@ -108,51 +160,132 @@ func (c *Calendar) InsertEvent(event *Event) (err error) {
 	eventRef := len(c.Events)
 	c.Events = append(c.Events, event)
 
-	if event.Rrule == "" {
+	// an event with a RECURRENCE-ID overrides instances of the recurring event with the same UID
+	if !event.RecurrenceID.IsZero() && event.ImportedID != "" {
+		c.OverridesByUID[event.ImportedID] = append(c.OverridesByUID[event.ImportedID], Index(eventRef))
+	}
 
-		// calculate the start and end day of the event
-		eventStartTime := event.Start
-		eventEndTime := event.End
-		tz := c.Timezone
-		eventStartDate := time.Date(eventStartTime.Year(), eventStartTime.Month(), eventStartTime.Day(), 0, 0, 0, 0, tz)
-		eventEndDate := time.Date(eventEndTime.Year(), eventEndTime.Month(), eventEndTime.Day(), 0, 0, 0, 0, tz)
+	if event.Rrule == "" && len(event.RDates) == 0 && c.masterOf(event) == nil {
 
-		// faster search by date, add each date from start to end date
-		for eventDate := eventStartDate; eventDate.Before(eventEndDate) || eventDate.Equal(eventEndDate); eventDate = eventDate.Add(24 * time.Hour) {
-			c.EventsByDate[eventDate.Format(YmdHis)] = append(c.EventsByDate[eventDate.Format(YmdHis)], Index(eventRef))
-		}
+		c.indexDates(Index(eventRef))
 
-		// faster search by id
-		c.EventsByID[event.ID] = Index(eventRef)
+	} else {
+		err = c.insertRecurring(Index(eventRef))
+	}
+
+	// faster search by id
+	c.EventsByID[event.ID] = Index(eventRef)
 
-		if event.ImportedID != "" {
+	if event.ImportedID != "" {
+		// the UID refers to the recurring event and not to one of its overrides
+		if _, exists := c.EventsByImportedID[event.ImportedID]; !exists || event.RecurrenceID.IsZero() {
 			c.EventsByImportedID[event.ImportedID] = Index(eventRef)
 		}
+	}
 
-	} else {
-		var rule *rrule.RRule
-		rule, err = rrule.StrToRRule(event.Rrule)
-		if err == nil {
-			err = rule.Compile(event.Start, event.End)
-			if err != nil {
-				err = fmt.Errorf("rule %s has error %s for event %s", event.Rrule, err.Error(), event.String())
-			}
-			c.RecurringEvents = append(c.RecurringEvents, Index(eventRef))
-			c.RecurringEventRules = append(c.RecurringEventRules, rule)
-		}
+	if event.RecurrenceID.IsZero() && err == nil {
+		err = c.insertFutureOverrides(event)
+	}
 
-		// faster search by id
-		c.EventsByID[event.ID] = Index(eventRef)
+	return err
+}
 
-		if event.ImportedID != "" {
-			c.EventsByImportedID[event.ImportedID] = Index(eventRef)
+// indexDates adds the event to the dates from its start to its end date
+func (c *Calendar) indexDates(eventRef Index) {
+	event := c.Events[eventRef]
+
+	// calculate the start and end day of the event in the timezone of the calendar
+	tz := c.Timezone
+	eventStartTime := event.Start.In(tz)
+	eventEndTime := event.End.In(tz)
+	eventEndDate := time.Date(eventEndTime.Year(), eventEndTime.Month(), eventEndTime.Day(), 0, 0, 0, 0, tz)
+
+	// faster search by date, add each date from start to end date, the dates are stepped
+	// through by calendar day since a day is not always 24 hours long
+	for day := 0; ; day++ {
+		eventDate := time.Date(eventStartTime.Year(), eventStartTime.Month(), eventStartTime.Day()+day, 0, 0, 0, 0, tz)
+		if eventDate.After(eventEndDate) {
+			break
 		}
+		c.EventsByDate[eventDate.Format(YmdHis)] = append(c.EventsByDate[eventDate.Format(YmdHis)], eventRef)
+	}
+}
 
+// unindexDates removes the event from the dates it was added to
+func (c *Calendar) unindexDates(eventRef Index) {
+	for date, events := range c.EventsByDate {
+		for i, e := range events {
+			if e == eventRef {
+				c.EventsByDate[date] = append(events[:i:i], events[i+1:]...)
+				break
+			}
+		}
 	}
+}
 
+// insertRecurring adds the recurrence set of the event to the recurring events
+func (c *Calendar) insertRecurring(eventRef Index) error {
+	event := c.Events[eventRef]
+	var set *rrule.Set
+	var err error
+	if master := c.masterOf(event); master != nil {
+		set, err = newFutureSet(master, event)
+	} else {
+		set, err = newEventSet(event)
+	}
+	if err != nil {
+		return err
+	}
+	err = set.Compile(event.Start, event.End)
+	if err != nil {
+		err = fmt.Errorf("rule %s has error %s for event %s", event.Rrule, err.Error(), event.String())
+	}
+	c.RecurringEventsByRef[eventRef] = len(c.RecurringEvents)
+	c.RecurringEvents = append(c.RecurringEvents, eventRef)
+	c.RecurringEventRules = append(c.RecurringEventRules, set)
 	return err
 }
 
+// newEventRule creates a rule of a recurring event, the rule starts at the start of the event
+func newEventRule(event *Event, value string) (*rrule.RRule, error) {
+	option, err := rrule.StrToROptionInLocation(value, event.Start.Location())
+	if err != nil {
+		return nil, err
+	}
+	option.Dtstart = event.Start
+	return rrule.NewRRule(*option)
+}
+
+// newEventSet creates the recurrence set of a recurring event from its RRULE, RDATE,
+// EXRULE and EXDATE values, without a RRULE the start of the event is an occurrence
+func newEventSet(event *Event) (*rrule.Set, error) {
+	set := &rrule.Set{}
+	set.DTStart(event.Start)
+	if event.Rrule != "" {
+		rule, err := newEventRule(event, event.Rrule)
+		if err != nil {
+			return nil, err
+		}
+		set.RRule(rule)
+	} else {
+		set.RDate(event.Start)
+	}
+	for _, rdate := range event.RDates {
+		set.RDate(rdate)
+	}
+	for _, value := range event.ExRules {
+		rule, err := newEventRule(event, value)
+		if err != nil {
+			return nil, err
+		}
+		set.ExRule(rule)
+	}
+	for _, exdate := range event.ExDates {
+		set.ExDate(exdate)
+	}
+	return set, nil
+}
+
 // GetEventByIndex get event by index
 func (c *Calendar) GetEventByIndex(e Index) (*Event, error) {
 	i := int(e)
@@ -191,7 +324,9 @@ func (c *Calendar) GetEventIndicesByDate(dateTime time.Time) []Index {
 	return []Index{}
 }
 
-// GetEventsFor get all active events for specified date
+// GetEventsFor get all active events for specified date, the recurring events are matched by
+// their compiled rule when one of their instances is in progress at the time, in the same way
+// for every frequency, use GetEventsOn to match them on the whole day
 func (c *Calendar) GetEventsFor(dateTime time.Time) []*Event {
 	tz := c.Timezone
 	day := time.Date(dateTime.Year(), dateTime.Month(), dateTime.Day(), 0, 0, 0, 0, tz)
@@ -213,7 +348,9 @@ func (c *Calendar) GetEventsFor(dateTime time.Time) []*Event {
 			rei := c.RecurringEvents[i]
 			event, err := c.GetEventByIndex(rei)
 			if err == nil {
-				today = append(today, event)
+				if !c.isOverridden(event, rer, dateTime) {
+					today = append(today, event)
+				}
 			} else {
 				fmt.Println(err)
 			}
@@ -0,0 +1,76 @@
+package icalendar
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInsertEventAcrossDST(t *testing.T) {
+	amsterdam, _ := time.LoadLocation("Europe/Amsterdam")
+	saoPaulo, _ := time.LoadLocation("America/Sao_Paulo")
+	tests := []struct {
+		location *time.Location
+		start    time.Time
+		days     int
+	}{
+		{amsterdam, time.Date(2021, 3, 27, 12, 0, 0, 0, amsterdam), 4},
+		{amsterdam, time.Date(2021, 10, 30, 12, 0, 0, 0, amsterdam), 4},
+		{saoPaulo, time.Date(2018, 11, 3, 12, 0, 0, 0, saoPaulo), 4},
+		{saoPaulo, time.Date(2019, 2, 15, 12, 0, 0, 0, saoPaulo), 4},
+	}
+	for _, test := range tests {
+		calendar := newCalendar("")
+		calendar.Timezone = test.location
+		event := NewEvent()
+		event.Start = test.start
+		event.End = test.start.AddDate(0, 0, test.days-1)
+		if err := calendar.InsertEvent(event); err != nil {
+			t.Fatal(err)
+		}
+		if len(calendar.EventsByDate) != test.days {
+			t.Errorf("Expected the event on %d days from %s, got %d", test.days, test.start, len(calendar.EventsByDate))
+		}
+		for day := 0; day < test.days; day++ {
+			date := test.start.AddDate(0, 0, day)
+			if events := calendar.GetEventsFor(date); len(events) != 1 {
+				t.Errorf("Expected 1 event on %s, got %d", date, len(events))
+			}
+		}
+	}
+}
+
+func TestGetEventsForMatchesEveryFrequencyAlike(t *testing.T) {
+	calendar := newCalendar("")
+	calendar.Timezone = time.UTC
+	for _, rule := range []string{"FREQ=DAILY", "FREQ=MONTHLY", "FREQ=YEARLY"} {
+		event := NewEvent()
+		event.Start = time.Date(2021, 7, 5, 9, 0, 0, 0, time.UTC)
+		event.End = time.Date(2021, 7, 5, 10, 0, 0, 0, time.UTC)
+		event.Rrule = rule
+		event.ImportedID = rule
+		if err := calendar.InsertEvent(event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if events := calendar.GetEventsFor(time.Date(2022, 7, 5, 9, 30, 0, 0, time.UTC)); len(events) != 3 {
+		t.Errorf("Expected 3 events in progress, got %d", len(events))
+	}
+	if events := calendar.GetEventsFor(time.Date(2022, 7, 5, 0, 0, 0, 0, time.UTC)); len(events) != 0 {
+		t.Errorf("Expected no events at midnight, got %d", len(events))
+	}
+}
+
+func TestIndexDatesInCalendarTimezone(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	calendar := newCalendar("")
+	calendar.Timezone = time.UTC
+	event := NewEvent()
+	event.Start = time.Date(2021, 7, 5, 23, 0, 0, 0, newYork)
+	event.End = time.Date(2021, 7, 5, 23, 30, 0, 0, newYork)
+	if err := calendar.InsertEvent(event); err != nil {
+		t.Fatal(err)
+	}
+	if len(calendar.EventsByDate) != 1 || len(calendar.EventsByDate["2021-07-06 00:00:00"]) != 1 {
+		t.Errorf("Expected the event on 2021-07-06, got %v", calendar.EventsByDate)
+	}
+}
@@ -0,0 +1,234 @@
+package icalendar
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Component is a BEGIN/END block of an iCalendar stream (VCALENDAR, VEVENT,
+// VTODO, VALARM, VTIMEZONE, X- components, ...). Properties and nested
+// components are kept in the order in which they were read.
+type Component struct {
+	Name       string
+	Properties []*Property
+	Components []*Component
+}
+
+// Property is a single content line of a component
+type Property struct {
+	Name   string
+	Params []*Parameter
+	Value  string
+}
+
+// Parameter is a property parameter, it can hold multiple values
+type Parameter struct {
+	Name   string
+	Values []string
+}
+
+// NewComponent will create a new, empty, Component
+func NewComponent(name string) *Component {
+	return &Component{Name: strings.ToUpper(name)}
+}
+
+// DecodeComponents reads all top-level components (normally a single VCALENDAR) from r
+func DecodeComponents(r io.Reader) ([]*Component, error) {
+	return decodeComponents(newLexer(r))
+}
+
+// decodeComponents reads all top-level components from the lexer
+func decodeComponents(l *lexer) ([]*Component, error) {
+	components := []*Component{}
+	for {
+		cl, err := l.next()
+		if err == io.EOF {
+			return components, nil
+		} else if err != nil {
+			return components, err
+		}
+		if cl.Name != "BEGIN" {
+			return components, fmt.Errorf("Line %d: expected BEGIN, found %s", l.line, cl.Name)
+		}
+		c, err := decodeComponent(l, strings.ToUpper(cl.Value))
+		if c != nil {
+			components = append(components, c)
+		}
+		if err != nil {
+			return components, err
+		}
+	}
+}
+
+// decodeComponent reads the content of a component up to its END line
+func decodeComponent(l *lexer, name string) (*Component, error) {
+	c := NewComponent(name)
+	for {
+		cl, err := l.next()
+		if err == io.EOF {
+			return c, fmt.Errorf("Component %s is not terminated", name)
+		} else if err != nil {
+			return c, err
+		}
+		switch cl.Name {
+		case "BEGIN":
+			sub, err := decodeComponent(l, strings.ToUpper(cl.Value))
+			c.Components = append(c.Components, sub)
+			if err != nil {
+				return c, err
+			}
+		case "END":
+			if strings.ToUpper(cl.Value) != name {
+				return c, fmt.Errorf("Line %d: component %s is terminated by END:%s", l.line, name, cl.Value)
+			}
+			return c, nil
+		default:
+			c.Properties = append(c.Properties, cl)
+		}
+	}
+}
+
+// GetProperty returns the first property with the given name, nil when there is none
+func (c *Component) GetProperty(name string) *Property {
+	name = strings.ToUpper(name)
+	for _, p := range c.Properties {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// GetProperties returns all properties with the given name
+func (c *Component) GetProperties(name string) []*Property {
+	name = strings.ToUpper(name)
+	props := []*Property{}
+	for _, p := range c.Properties {
+		if p.Name == name {
+			props = append(props, p)
+		}
+	}
+	return props
+}
+
+// PropertyValue returns the value of the first property with the given name
+func (c *Component) PropertyValue(name string) string {
+	p := c.GetProperty(name)
+	if p == nil {
+		return ""
+	}
+	return p.Value
+}
+
+// GetComponents returns all direct sub-components with the given name
+func (c *Component) GetComponents(name string) []*Component {
+	name = strings.ToUpper(name)
+	comps := []*Component{}
+	for _, sub := range c.Components {
+		if sub.Name == name {
+			comps = append(comps, sub)
+		}
+	}
+	return comps
+}
+
+// AddProperty appends a property to the component
+func (c *Component) AddProperty(p *Property) {
+	c.Properties = append(c.Properties, p)
+}
+
+// AddComponent appends a sub-component to the component
+func (c *Component) AddComponent(sub *Component) {
+	c.Components = append(c.Components, sub)
+}
+
+// String returns the component in iCalendar format, lines are folded at 75 octets
+func (c *Component) String() string {
+	b := &strings.Builder{}
+	c.encode(b)
+	return b.String()
+}
+
+func (c *Component) encode(b *strings.Builder) {
+	writeFolded(b, "BEGIN:"+c.Name)
+	for _, p := range c.Properties {
+		writeFolded(b, p.String())
+	}
+	for _, sub := range c.Components {
+		sub.encode(b)
+	}
+	writeFolded(b, "END:"+c.Name)
+}
+
+// writeFolded writes a content line, folding it so that no line is longer
+// than 75 octets (without splitting UTF-8 sequences)
+func writeFolded(b *strings.Builder, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+// GetParameter returns the parameter with the given name, nil when there is none
+func (p *Property) GetParameter(name string) *Parameter {
+	name = strings.ToUpper(name)
+	for _, param := range p.Params {
+		if param.Name == name {
+			return param
+		}
+	}
+	return nil
+}
+
+// ParamValue returns the first value of the parameter with the given name
+func (p *Property) ParamValue(name string) string {
+	param := p.GetParameter(name)
+	if param == nil {
+		return ""
+	}
+	return param.Value()
+}
+
+// String returns the property as an (unfolded) content line
+func (p *Property) String() string {
+	b := &strings.Builder{}
+	b.WriteString(p.Name)
+	for _, param := range p.Params {
+		b.WriteString(";")
+		b.WriteString(param.String())
+	}
+	b.WriteString(":")
+	b.WriteString(p.Value)
+	return b.String()
+}
+
+// Value returns the first value of the parameter
+func (p *Parameter) Value() string {
+	if len(p.Values) == 0 {
+		return ""
+	}
+	return p.Values[0]
+}
+
+// String returns the parameter as it appears in a content line, values
+// that contain a ':', ';' or ',' are quoted
+func (p *Parameter) String() string {
+	values := make([]string, len(p.Values))
+	for i, v := range p.Values {
+		if strings.ContainsAny(v, ":;,") {
+			v = `"` + v + `"`
+		}
+		values[i] = v
+	}
+	return p.Name + "=" + strings.Join(values, ",")
+}
@@ -0,0 +1,89 @@
+package icalendar
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCalendarKeepsComponentTree(t *testing.T) {
+	reader := readingFromFile("testCalendars/2eventsCal.ics")
+	parser := createParser(reader)
+	calendar := newCalendar("")
+	parser.read(calendar)
+
+	root := calendar.Component
+	if root == nil || root.Name != "VCALENDAR" {
+		t.Fatalf("Expected the VCALENDAR component on the calendar, got %v", root)
+	}
+	if prodID := root.PropertyValue("PRODID"); prodID != "-//Google Inc//Google Calendar 70.9054//EN" {
+		t.Errorf("Expected PRODID to be kept, got '%s'", prodID)
+	}
+
+	timezones := root.GetComponents("VTIMEZONE")
+	if len(timezones) != 1 {
+		t.Fatalf("Expected 1 VTIMEZONE, got %d", len(timezones))
+	}
+	if len(timezones[0].GetComponents("DAYLIGHT")) != 1 || len(timezones[0].GetComponents("STANDARD")) != 1 {
+		t.Errorf("Expected the DAYLIGHT and STANDARD observances to be kept")
+	}
+
+	ievent, _ := calendar.GetEventIndexByImportedID("btb9tnpcnd4ng9rn31rdo0irn8@google.com")
+	event, _ := calendar.GetEventByIndex(ievent)
+	if event.Component == nil {
+		t.Fatalf("Expected the VEVENT component on the event")
+	}
+	if transp := event.Component.PropertyValue("TRANSP"); transp != "OPAQUE" {
+		t.Errorf("Expected TRANSP OPAQUE, got '%s'", transp)
+	}
+	attendee := event.Component.GetProperties("ATTENDEE")[0]
+	if guests := attendee.ParamValue("X-NUM-GUESTS"); guests != "0" {
+		t.Errorf("Expected X-NUM-GUESTS 0, got '%s'", guests)
+	}
+}
+
+func TestComponentUnknownComponents(t *testing.T) {
+	content := "BEGIN:VCALENDAR\r\nBEGIN:X-WIDGET\r\nX-COLOR:red\r\nBEGIN:VALARM\r\nACTION:DISPLAY\r\nEND:VALARM\r\nEND:X-WIDGET\r\nEND:VCALENDAR\r\n"
+	components, err := DecodeComponents(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	widgets := components[0].GetComponents("x-widget")
+	if len(widgets) != 1 {
+		t.Fatalf("Expected 1 X-WIDGET component, got %d", len(widgets))
+	}
+	if widgets[0].PropertyValue("X-COLOR") != "red" {
+		t.Errorf("Expected X-COLOR red, got '%s'", widgets[0].PropertyValue("X-COLOR"))
+	}
+	if len(widgets[0].GetComponents("VALARM")) != 1 {
+		t.Errorf("Expected a nested VALARM component")
+	}
+}
+
+func TestComponentRoundTrip(t *testing.T) {
+	content := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"ATTENDEE;CN=\"Doe, John\";ROLE=REQ-PARTICIPANT:mailto:john@example.com\r\n" +
+		"DESCRIPTION:" + strings.Repeat("0123456789", 12) + "\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+	components, err := DecodeComponents(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+
+	encoded := components[0].String()
+	for _, line := range strings.Split(encoded, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("Expected folded lines of at most 75 octets, got %d", len(line))
+		}
+	}
+
+	decoded, err := DecodeComponents(strings.NewReader(encoded))
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if !reflect.DeepEqual(components, decoded) {
+		t.Errorf("Expected the encoded component to decode to the same tree:\n%s", encoded)
+	}
+}
@@ -0,0 +1,63 @@
+package icalendar
+
+import (
+	"time"
+)
+
+// TimeKind tells how a date or date-time value was written in the calendar
+type TimeKind int
+
+const (
+	// ZonedTime is a date-time with a TZID parameter, it is a fixed instant
+	ZonedTime TimeKind = iota
+	// UTCTime is a date-time in UTC (ending with 'Z'), it is a fixed instant
+	UTCTime
+	// FloatingTime is a date-time without TZID or 'Z', it happens at the same wall clock time in every timezone
+	FloatingTime
+	// DateValue is a date without a time (VALUE=DATE), like the day of a whole day event
+	DateValue
+)
+
+func (k TimeKind) String() string {
+	switch k {
+	case ZonedTime:
+		return "ZONED"
+	case UTCTime:
+		return "UTC"
+	case FloatingTime:
+		return "FLOATING"
+	case DateValue:
+		return "DATE"
+	}
+	return "UNKNOWN"
+}
+
+// IsFloating returns true for values that are not bound to a timezone, floating
+// date-times and dates
+func (k TimeKind) IsFloating() bool {
+	return k == FloatingTime || k == DateValue
+}
+
+// Resolve returns the instant of a value of this kind in the given location, floating
+// values and dates keep their wall clock, fixed instants are returned as they are
+func (k TimeKind) Resolve(t time.Time, loc *time.Location) time.Time {
+	if !k.IsFloating() || t.IsZero() || loc == nil {
+		return t
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
+// StartIn returns the start of the event, a floating or date start is resolved in the given location
+func (e *Event) StartIn(loc *time.Location) time.Time {
+	return e.StartKind.Resolve(e.Start, loc)
+}
+
+// EndIn returns the end of the event, a floating or date end is resolved in the given location
+func (e *Event) EndIn(loc *time.Location) time.Time {
+	return e.EndKind.Resolve(e.End, loc)
+}
+
+// RecurrenceIDIn returns the recurrence id of the event, a floating or date value is resolved in the given location
+func (e *Event) RecurrenceIDIn(loc *time.Location) time.Time {
+	return e.RecurrenceIDKind.Resolve(e.RecurrenceID, loc)
+}
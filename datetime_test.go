@@ -0,0 +1,65 @@
+package icalendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEventTimeKinds(t *testing.T) {
+	content := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"X-WR-TIMEZONE:Europe/Amsterdam",
+		"BEGIN:VEVENT",
+		"UID:midnight",
+		"DTSTART;TZID=Europe/Sofia:20210705T000000",
+		"DTEND:20210705T010000Z",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:wholeday",
+		"DTSTART;VALUE=DATE:20210705",
+		"DTEND;VALUE=DATE:20210706",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:floating",
+		"DTSTART:20210705T090000",
+		"DTEND:20210705T100000",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+	calendar := parseTestCalendar(t, content)
+
+	ievent, _ := calendar.GetEventIndexByImportedID("midnight")
+	midnight, _ := calendar.GetEventByIndex(ievent)
+	if midnight.IsWholeDayEvent {
+		t.Errorf("Expected a meeting at midnight not to be a whole day event")
+	}
+	if midnight.StartKind != ZonedTime || midnight.EndKind != UTCTime {
+		t.Errorf("Expected ZONED start and UTC end, got %s and %s", midnight.StartKind, midnight.EndKind)
+	}
+
+	ievent, _ = calendar.GetEventIndexByImportedID("wholeday")
+	wholeDay, _ := calendar.GetEventByIndex(ievent)
+	if !wholeDay.IsWholeDayEvent {
+		t.Errorf("Expected a VALUE=DATE event to be a whole day event")
+	}
+	if wholeDay.StartKind != DateValue || wholeDay.EndKind != DateValue {
+		t.Errorf("Expected DATE start and end, got %s and %s", wholeDay.StartKind, wholeDay.EndKind)
+	}
+
+	ievent, _ = calendar.GetEventIndexByImportedID("floating")
+	floating, _ := calendar.GetEventByIndex(ievent)
+	if floating.IsWholeDayEvent || floating.StartKind != FloatingTime {
+		t.Errorf("Expected a FLOATING start, got %s", floating.StartKind)
+	}
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	if want := time.Date(2021, 7, 5, 9, 0, 0, 0, tokyo); !floating.StartIn(tokyo).Equal(want) {
+		t.Errorf("Expected floating start %s in Tokyo, got %s", want, floating.StartIn(tokyo))
+	}
+	if want := time.Date(2021, 7, 5, 0, 0, 0, 0, tokyo); !wholeDay.StartIn(tokyo).Equal(want) {
+		t.Errorf("Expected whole day start %s in Tokyo, got %s", want, wholeDay.StartIn(tokyo))
+	}
+	if !midnight.StartIn(tokyo).Equal(midnight.Start) {
+		t.Errorf("Expected a zoned start not to change, got %s", midnight.StartIn(tokyo))
+	}
+}
@@ -0,0 +1,145 @@
+package icalendar
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Duration is an iCalendar DURATION value (RFC 5545 section 3.3.6), the parts are
+// kept as they were written so that 'P1W' and 'P7D' or 'PT90M' and 'PT1H30M'
+// are written back the same way
+type Duration struct {
+	Negative bool
+	Weeks    int
+	Days     int
+	Hours    int
+	Minutes  int
+	Seconds  int
+}
+
+// ParseDuration parses a DURATION value like 'PT1H30M', 'P1D', 'P2W' or '-PT15M'
+func ParseDuration(value string) (*Duration, error) {
+	d := &Duration{}
+	str := value
+	if strings.HasPrefix(str, "-") {
+		d.Negative = true
+		str = str[1:]
+	} else if strings.HasPrefix(str, "+") {
+		str = str[1:]
+	}
+	if !strings.HasPrefix(str, "P") || len(str) == 1 {
+		return nil, fmt.Errorf("Invalid duration '%s'", value)
+	}
+	str = str[1:]
+
+	inTime := false
+	number := ""
+	parts := 0
+	timeParts := 0
+	for _, c := range str {
+		switch {
+		case c >= '0' && c <= '9':
+			number += string(c)
+			continue
+		case c == 'T' && !inTime && number == "":
+			inTime = true
+			continue
+		}
+		if number == "" {
+			return nil, fmt.Errorf("Invalid duration '%s'", value)
+		}
+		n, _ := strconv.Atoi(number)
+		switch {
+		case c == 'W' && !inTime:
+			d.Weeks = n
+		case c == 'D' && !inTime:
+			d.Days = n
+		case c == 'H' && inTime:
+			d.Hours = n
+		case c == 'M' && inTime:
+			d.Minutes = n
+		case c == 'S' && inTime:
+			d.Seconds = n
+		default:
+			return nil, fmt.Errorf("Invalid duration '%s'", value)
+		}
+		number = ""
+		parts++
+		if inTime {
+			timeParts++
+		}
+	}
+	// a T starts the time and has to be followed by hours, minutes or seconds
+	if number != "" || parts == 0 || inTime && timeParts == 0 {
+		return nil, fmt.Errorf("Invalid duration '%s'", value)
+	}
+	return d, nil
+}
+
+// String returns the duration as a DURATION value
+func (d *Duration) String() string {
+	b := &strings.Builder{}
+	if d.Negative {
+		b.WriteByte('-')
+	}
+	b.WriteByte('P')
+	if d.Weeks != 0 {
+		fmt.Fprintf(b, "%dW", d.Weeks)
+	}
+	if d.Days != 0 {
+		fmt.Fprintf(b, "%dD", d.Days)
+	}
+	if d.Hours != 0 || d.Minutes != 0 || d.Seconds != 0 {
+		b.WriteByte('T')
+		if d.Hours != 0 {
+			fmt.Fprintf(b, "%dH", d.Hours)
+		}
+		if d.Minutes != 0 {
+			fmt.Fprintf(b, "%dM", d.Minutes)
+		}
+		if d.Seconds != 0 {
+			fmt.Fprintf(b, "%dS", d.Seconds)
+		}
+	} else if d.Weeks == 0 && d.Days == 0 {
+		b.WriteString("T0S")
+	}
+	return b.String()
+}
+
+// NewDuration creates a Duration from a time.Duration, whole days are not used
+// since their length depends on the timezone
+func NewDuration(duration time.Duration) *Duration {
+	d := &Duration{}
+	if duration < 0 {
+		d.Negative = true
+		duration = -duration
+	}
+	seconds := int(duration / time.Second)
+	d.Hours = seconds / 3600
+	d.Minutes = (seconds % 3600) / 60
+	d.Seconds = seconds % 60
+	return d
+}
+
+// Exact returns the duration as a time.Duration, weeks and days are taken as 24 hours
+func (d *Duration) Exact() time.Duration {
+	days := d.Weeks*7 + d.Days
+	duration := time.Duration(days)*24*time.Hour + time.Duration(d.Hours)*time.Hour + time.Duration(d.Minutes)*time.Minute + time.Duration(d.Seconds)*time.Second
+	if d.Negative {
+		return -duration
+	}
+	return duration
+}
+
+// AddTo adds the duration to a time, weeks and days are nominal and keep the wall
+// clock when crossing a daylight saving time transition
+func (d *Duration) AddTo(t time.Time) time.Time {
+	sign := 1
+	if d.Negative {
+		sign = -1
+	}
+	exact := time.Duration(d.Hours)*time.Hour + time.Duration(d.Minutes)*time.Minute + time.Duration(d.Seconds)*time.Second
+	return t.AddDate(0, 0, sign*(d.Weeks*7+d.Days)).Add(time.Duration(sign) * exact)
+}
@@ -0,0 +1,97 @@
+package icalendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	cases := []struct {
+		value string
+		exact time.Duration
+	}{
+		{"PT1H30M", 90 * time.Minute},
+		{"PT90M", 90 * time.Minute},
+		{"P1D", 24 * time.Hour},
+		{"P2W", 14 * 24 * time.Hour},
+		{"-PT15M", -15 * time.Minute},
+		{"+P1DT2H3M4S", 26*time.Hour + 3*time.Minute + 4*time.Second},
+		{"PT0S", 0},
+	}
+	for _, c := range cases {
+		d, err := ParseDuration(c.value)
+		if err != nil {
+			t.Errorf("ParseDuration(%q) has unexpected error %s", c.value, err)
+			continue
+		}
+		if d.Exact() != c.exact {
+			t.Errorf("ParseDuration(%q) = %s, want %s", c.value, d.Exact(), c.exact)
+		}
+		if want := strings.TrimPrefix(c.value, "+"); d.String() != want {
+			t.Errorf("Expected %q to be written back as %q, got %q", c.value, want, d.String())
+		}
+	}
+
+	for _, value := range []string{"", "P", "PT", "1H", "PT1D", "P1H", "PT1H30", "P-1D", "PTM", "P1DT"} {
+		if _, err := ParseDuration(value); err == nil {
+			t.Errorf("ParseDuration(%q) = nil, want error", value)
+		}
+	}
+
+	if d := NewDuration(-(90*time.Minute + 5*time.Second)); d.String() != "-PT1H30M5S" {
+		t.Errorf("Expected -PT1H30M5S, got %s", d)
+	}
+}
+
+func TestDurationAddToKeepsWallClock(t *testing.T) {
+	amsterdam, _ := time.LoadLocation("Europe/Amsterdam")
+	start := time.Date(2021, 3, 27, 9, 0, 0, 0, amsterdam)
+	d, _ := ParseDuration("P1DT1H")
+	if want := time.Date(2021, 3, 28, 10, 0, 0, 0, amsterdam); !d.AddTo(start).Equal(want) {
+		t.Errorf("Expected %s, got %s", want, d.AddTo(start))
+	}
+	d, _ = ParseDuration("-P1W")
+	if want := time.Date(2021, 3, 20, 9, 0, 0, 0, amsterdam); !d.AddTo(start).Equal(want) {
+		t.Errorf("Expected %s, got %s", want, d.AddTo(start))
+	}
+}
+
+func TestEventDuration(t *testing.T) {
+	content := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:meeting",
+		"DTSTART:20210705T090000Z",
+		"DURATION:PT1H30M",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:holiday",
+		"DTSTART;VALUE=DATE:20210705",
+		"DURATION:P1D",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+	calendar := parseTestCalendar(t, content)
+
+	ievent, _ := calendar.GetEventIndexByImportedID("meeting")
+	meeting, _ := calendar.GetEventByIndex(ievent)
+	if want := time.Date(2021, 7, 5, 10, 30, 0, 0, time.UTC); !meeting.End.Equal(want) {
+		t.Errorf("Expected end %s, found %s", want, meeting.End)
+	}
+	if meeting.Duration == nil || meeting.Duration.String() != "PT1H30M" {
+		t.Errorf("Expected duration PT1H30M, found %v", meeting.Duration)
+	}
+
+	ievent, _ = calendar.GetEventIndexByImportedID("holiday")
+	holiday, _ := calendar.GetEventByIndex(ievent)
+	if !holiday.IsWholeDayEvent || holiday.EndKind != DateValue {
+		t.Errorf("Expected a whole day event ending on a DATE, found %s", holiday.EndKind)
+	}
+	if want := holiday.Start.AddDate(0, 0, 1); !holiday.End.Equal(want) {
+		t.Errorf("Expected end %s, found %s", want, holiday.End)
+	}
+	if len(calendar.GetEventIndicesByDate(holiday.Start)) != 2 {
+		t.Errorf("Expected both events to be indexed by their date")
+	}
+}
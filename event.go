@@ -8,27 +8,37 @@ import (
 
 // Event holds all information for a Calendar Event
 type Event struct {
-	Start           time.Time
-	End             time.Time
-	Created         time.Time
-	Modified        time.Time
-	RecurrenceID    time.Time
-	AlarmTime       time.Duration
-	ImportedID      string
-	Status          string
-	Description     string
-	Location        string
-	Geo             *Geo
-	Summary         string
-	Rrule           string
-	Class           string
-	ID              string
-	Sequence        int
-	Attendees       []*Attendee
-	Organizer       *Attendee
-	IsWholeDayEvent bool
-	Owner           *Calendar
-	AlarmCallback   func(*Event)
+	Start            time.Time
+	StartKind        TimeKind
+	End              time.Time
+	EndKind          TimeKind
+	Duration         *Duration
+	Created          time.Time
+	Modified         time.Time
+	RecurrenceID     time.Time
+	RecurrenceIDKind TimeKind
+	ThisAndFuture    bool
+	ExDates          []time.Time
+	RDates           []time.Time
+	AlarmTime        time.Duration
+	ImportedID       string
+	Status           string
+	Description      string
+	Location         string
+	Categories       []string
+	Geo              *Geo
+	Summary          string
+	Rrule            string
+	ExRules          []string
+	Class            string
+	ID               string
+	Sequence         int
+	Attendees        []*Attendee
+	Organizer        *Attendee
+	IsWholeDayEvent  bool
+	Owner            *Calendar
+	Component        *Component
+	AlarmCallback    func(*Event)
 }
 
 // NewEvent will create a new instance of Event
@@ -0,0 +1,150 @@
+package icalendar
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// lexer reads content lines from a stream, unfolding lines that are
+// continued on the next physical line
+type lexer struct {
+	reader      *bufio.Reader
+	pending     string
+	hasLine     bool
+	line        int // number of the last physical line read
+	pendingLine int // number of the physical line in pending
+	start       int // number of the physical line the last logical line starts on
+}
+
+func newLexer(r io.Reader) *lexer {
+	return &lexer{reader: bufio.NewReader(r)}
+}
+
+// readPhysicalLine returns the next line of the stream without its line ending
+func (l *lexer) readPhysicalLine() (string, error) {
+	line, err := l.reader.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	l.line++
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// nextLine returns the next logical (unfolded) line
+func (l *lexer) nextLine() (string, error) {
+	for {
+		if !l.hasLine {
+			line, err := l.readPhysicalLine()
+			if err != nil {
+				return "", err
+			}
+			l.pending = line
+			l.pendingLine = l.line
+			l.hasLine = true
+		}
+
+		// the lookahead below reads past the line, so its number is recorded here
+		current := l.pending
+		l.start = l.pendingLine
+		l.hasLine = false
+		for {
+			line, err := l.readPhysicalLine()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return "", err
+			}
+			if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') {
+				current += line[1:]
+				continue
+			}
+			l.pending = line
+			l.pendingLine = l.line
+			l.hasLine = true
+			break
+		}
+
+		// skip empty lines, they are not allowed but do occur in the wild
+		if strings.TrimSpace(current) != "" {
+			return current, nil
+		}
+	}
+}
+
+// next returns the next content line, io.EOF is returned at the end of the stream
+func (l *lexer) next() (*Property, error) {
+	line, err := l.nextLine()
+	if err != nil {
+		return nil, err
+	}
+	cl, err := parseContentLine(line)
+	if err != nil {
+		return nil, fmt.Errorf("Line %d: %s", l.start, err)
+	}
+	return cl, nil
+}
+
+func isNameChar(c byte) bool {
+	return c == '-' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// parseContentLine splits a logical line into name, parameters and value
+//
+//	contentline = name *(";" param ) ":" value
+//	param       = param-name "=" param-value *("," param-value)
+func parseContentLine(line string) (*Property, error) {
+	i := 0
+	for i < len(line) && isNameChar(line[i]) {
+		i++
+	}
+	if i == 0 {
+		return nil, fmt.Errorf("Content line '%s' has no name", line)
+	}
+	cl := &Property{Name: strings.ToUpper(line[:i])}
+
+	for i < len(line) && line[i] == ';' {
+		i++
+		start := i
+		for i < len(line) && isNameChar(line[i]) {
+			i++
+		}
+		if i == start || i >= len(line) || line[i] != '=' {
+			return nil, fmt.Errorf("Content line '%s' has a malformed parameter", line)
+		}
+		param := &Parameter{Name: strings.ToUpper(line[start:i])}
+		i++
+
+		for {
+			var value string
+			if i < len(line) && line[i] == '"' {
+				end := strings.IndexByte(line[i+1:], '"')
+				if end < 0 {
+					return nil, fmt.Errorf("Content line '%s' has an unterminated quoted parameter value", line)
+				}
+				value = line[i+1 : i+1+end]
+				i += end + 2
+			} else {
+				start = i
+				for i < len(line) && line[i] != ',' && line[i] != ';' && line[i] != ':' {
+					i++
+				}
+				value = line[start:i]
+			}
+			param.Values = append(param.Values, value)
+			if i < len(line) && line[i] == ',' {
+				i++
+				continue
+			}
+			break
+		}
+		cl.Params = append(cl.Params, param)
+	}
+
+	if i >= len(line) || line[i] != ':' {
+		return nil, fmt.Errorf("Content line '%s' has no value", line)
+	}
+	cl.Value = line[i+1:]
+	return cl, nil
+}
@@ -0,0 +1,112 @@
+package icalendar
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLexerUnfoldsLines(t *testing.T) {
+	content := "DESCRIPTION:This is a lo\r\n ng description\r\n\tthat spans three lines\r\nSUMMARY:Short\r\n"
+	l := newLexer(strings.NewReader(content))
+
+	cl, err := l.next()
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if cl.Name != "DESCRIPTION" || cl.Value != "This is a long descriptionthat spans three lines" {
+		t.Errorf("Expected unfolded DESCRIPTION, got %s:%s", cl.Name, cl.Value)
+	}
+
+	cl, err = l.next()
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if cl.Name != "SUMMARY" || cl.Value != "Short" {
+		t.Errorf("Expected SUMMARY:Short, got %s:%s", cl.Name, cl.Value)
+	}
+
+	if _, err = l.next(); err != io.EOF {
+		t.Errorf("Expected io.EOF at the end of the content, got %v", err)
+	}
+}
+
+func TestParseContentLineParameters(t *testing.T) {
+	cl, err := parseContentLine(`attendee;CN="Doe, John";MEMBER="mailto:a@x.org","mailto:b@x.org";ROLE=CHAIR:mailto:john@x.org`)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if cl.Name != "ATTENDEE" {
+		t.Errorf("Expected name ATTENDEE, got %s", cl.Name)
+	}
+	if len(cl.Params) != 3 {
+		t.Fatalf("Expected 3 parameters, got %d", len(cl.Params))
+	}
+	if cl.ParamValue("CN") != "Doe, John" {
+		t.Errorf("Expected quoted CN 'Doe, John', got '%s'", cl.ParamValue("CN"))
+	}
+	if members := cl.Params[1].Values; len(members) != 2 || members[0] != "mailto:a@x.org" || members[1] != "mailto:b@x.org" {
+		t.Errorf("Expected 2 MEMBER values, got %v", members)
+	}
+	if cl.ParamValue("ROLE") != "CHAIR" {
+		t.Errorf("Expected ROLE CHAIR, got '%s'", cl.ParamValue("ROLE"))
+	}
+	if cl.Value != "mailto:john@x.org" {
+		t.Errorf("Expected value mailto:john@x.org, got %s", cl.Value)
+	}
+}
+
+func TestParseContentLineErrors(t *testing.T) {
+	cases := []string{
+		":no name",
+		"SUMMARY",
+		"ATTENDEE;CN:mailto:a@x.org",
+		`ATTENDEE;CN="unterminated:mailto:a@x.org`,
+	}
+	for _, line := range cases {
+		if _, err := parseContentLine(line); err == nil {
+			t.Errorf("parseContentLine(%q) = nil, want error", line)
+		}
+	}
+}
+
+func TestLexerErrorReportsStartLine(t *testing.T) {
+	content := "SUMMARY:Short\r\nDESCRIPTION;CN:folded\r\n  over two lines\r\nLOCATION:Here\r\n"
+	l := newLexer(strings.NewReader(content))
+
+	if _, err := l.next(); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	_, err := l.next()
+	if err == nil || !strings.HasPrefix(err.Error(), "Line 2: ") {
+		t.Errorf("Expected an error on line 2, got %v", err)
+	}
+	if cl, err := l.next(); err != nil || cl.Name != "LOCATION" {
+		t.Errorf("Expected LOCATION after the malformed line, got %v, %v", cl, err)
+	}
+}
+
+func TestDecodeComponentsIgnoresNestedNames(t *testing.T) {
+	content := "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDESCRIPTION:SUMMARY:not the summary\nX-SUMMARY:neither\nSUMMARY:The summary\nEND:VEVENT\nEND:VCALENDAR\n"
+	components, err := decodeComponents(newLexer(strings.NewReader(content)))
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if len(components) != 1 || components[0].Name != "VCALENDAR" {
+		t.Fatalf("Expected a single VCALENDAR component, got %d", len(components))
+	}
+	events := components[0].GetComponents("VEVENT")
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 VEVENT, got %d", len(events))
+	}
+	if summary := events[0].PropertyValue("SUMMARY"); summary != "The summary" {
+		t.Errorf("Expected summary 'The summary', got '%s'", summary)
+	}
+}
+
+func TestDecodeComponentsMismatchedEnd(t *testing.T) {
+	content := "BEGIN:VCALENDAR\nBEGIN:VEVENT\nEND:VCALENDAR\n"
+	if _, err := decodeComponents(newLexer(strings.NewReader(content))); err == nil {
+		t.Errorf("Expected an error for a mismatched END line")
+	}
+}
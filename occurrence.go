@@ -0,0 +1,158 @@
+package icalendar
+
+import (
+	"sort"
+	"time"
+)
+
+// Occurrence is a single instance of an event, for a recurring event it holds the start and
+// end of the instance instead of the ones of the first instance
+type Occurrence struct {
+	Start time.Time
+	End   time.Time
+	// Event is the event with the details of the instance, it is the override when the
+	// instance is overridden (RECURRENCE-ID) and the master otherwise
+	Event *Event
+	// Master is the recurring event of the instance, for a single event it is the event
+	Master *Event
+	// RecurrenceID is the start of the instance in the recurrence of the master
+	RecurrenceID time.Time
+	Overridden   bool
+	// Index is the number of the instance in its recurrence starting at 0
+	Index int
+}
+
+// Occurrences returns the instances of the single and the recurring events that take place
+// between from and to, sorted by their start
+func (c *Calendar) Occurrences(from time.Time, to time.Time) []Occurrence {
+	occurrences := []Occurrence{}
+	for i, event := range c.Events {
+		if c.isRecurring(Index(i)) || c.hasRecurringMaster(event) {
+			continue
+		}
+		o := Occurrence{Start: event.Start, End: event.End, Event: event, Master: event, RecurrenceID: event.Start}
+		if o.overlaps(from, to) {
+			occurrences = append(occurrences, o)
+		}
+	}
+
+	for i, set := range c.RecurringEventRules {
+		event := c.Events[c.RecurringEvents[i]]
+		duration := event.End.Sub(event.Start)
+		// the instances that end before from or start after to are only counted for the index,
+		// unless an override moves them into the window
+		moved, last := c.movedInto(event, from, to)
+		next := set.Iterator()
+		index := -1
+		for start, ok := next(); ok; start, ok = next() {
+			index++
+			if start.Add(duration).Before(from) || !start.Before(to) {
+				original := c.originalOf(event, start)
+				if !start.Before(to) && original.After(last) {
+					break
+				}
+				if !moved[original.UnixNano()] {
+					continue
+				}
+			}
+			o := Occurrence{Start: start, End: start.Add(duration), Event: event, Master: event, RecurrenceID: start, Index: index}
+			if !event.RecurrenceID.IsZero() {
+				// the instances of a RANGE=THISANDFUTURE override belong to the recurring event
+				o.RecurrenceID = start.Add(event.RecurrenceID.Sub(event.Start))
+				o.Overridden = true
+				if master, ok := c.EventsByImportedID[event.ImportedID]; ok {
+					o.Master = c.Events[master]
+				}
+			}
+			override, superseded := c.overrideOf(event, start)
+			if superseded {
+				continue
+			}
+			if override != nil {
+				o.Start, o.End, o.Event, o.Overridden = override.Start, override.End, override, true
+			}
+			if o.overlaps(from, to) {
+				occurrences = append(occurrences, o)
+			}
+		}
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].Start.Before(occurrences[j].Start)
+	})
+	return occurrences
+}
+
+// movedInto returns the recurrence ids of the overrides of the recurring event that take
+// place between from and to, and the latest of them
+func (c *Calendar) movedInto(event *Event, from time.Time, to time.Time) (map[int64]bool, time.Time) {
+	moved := map[int64]bool{}
+	var last time.Time
+	for _, i := range c.OverridesByUID[event.ImportedID] {
+		o := c.Events[i]
+		if o == event || o.RecurrenceID.IsZero() {
+			continue
+		}
+		if (Occurrence{Start: o.Start, End: o.End}).overlaps(from, to) {
+			moved[o.RecurrenceID.UnixNano()] = true
+			if o.RecurrenceID.After(last) {
+				last = o.RecurrenceID
+			}
+		}
+	}
+	return moved, last
+}
+
+// originalOf returns the start that the instance of the recurring event that starts at start
+// has in the original recurrence, that is its RECURRENCE-ID
+func (c *Calendar) originalOf(event *Event, start time.Time) time.Time {
+	if event.RecurrenceID.IsZero() {
+		return start
+	}
+	return start.Add(event.RecurrenceID.Sub(event.Start))
+}
+
+// hasRecurringMaster returns true when the event overrides an instance of a recurring event
+// in the calendar
+func (c *Calendar) hasRecurringMaster(event *Event) bool {
+	if event.RecurrenceID.IsZero() || event.ImportedID == "" {
+		return false
+	}
+	i, ok := c.EventsByImportedID[event.ImportedID]
+	return ok && c.Events[i] != event && c.isRecurring(i)
+}
+
+// overlaps returns true when the occurrence takes place between from and to, an occurrence
+// without duration takes place when it starts between from and to
+func (o Occurrence) overlaps(from time.Time, to time.Time) bool {
+	if !o.End.After(o.Start) {
+		return !o.Start.Before(from) && o.Start.Before(to)
+	}
+	return o.Start.Before(to) && o.End.After(from)
+}
+
+// GetEventsOn returns the events that take place (partly) on the day of the date in the
+// timezone of the calendar, for a recurring event one of its instances takes place on the day
+func (c *Calendar) GetEventsOn(date time.Time) []*Event {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, c.Timezone)
+	return occurrenceEvents(c.Occurrences(day, day.AddDate(0, 0, 1)))
+}
+
+// GetEventsAt returns the events that are in progress at the instant, for a recurring event
+// one of its instances is in progress at the instant
+func (c *Calendar) GetEventsAt(instant time.Time) []*Event {
+	return occurrenceEvents(c.Occurrences(instant, instant.Add(time.Nanosecond)))
+}
+
+// occurrenceEvents returns the events of the occurrences, every event is returned once
+func occurrenceEvents(occurrences []Occurrence) []*Event {
+	events := []*Event{}
+	seen := map[*Event]bool{}
+	for _, o := range occurrences {
+		if !seen[o.Event] {
+			seen[o.Event] = true
+			events = append(events, o.Event)
+		}
+	}
+	return events
+}
@@ -0,0 +1,181 @@
+package icalendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOccurrences(t *testing.T) {
+	content := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:weekly",
+		"SUMMARY:Weekly",
+		"DTSTART;TZID=Europe/Amsterdam:20180702T090000",
+		"DTEND;TZID=Europe/Amsterdam:20180702T100000",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO",
+		"EXDATE;TZID=Europe/Amsterdam:20260713T090000",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:weekly",
+		"SUMMARY:Moved",
+		"RECURRENCE-ID;TZID=Europe/Amsterdam:20260720T090000",
+		"DTSTART;TZID=Europe/Amsterdam:20260721T140000",
+		"DTEND;TZID=Europe/Amsterdam:20260721T150000",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:lunch",
+		"SUMMARY:Lunch",
+		"DTSTART:20260708T110000Z",
+		"DTEND:20260708T120000Z",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+	calendar := parseTestCalendar(t, content)
+
+	amsterdam, _ := time.LoadLocation("Europe/Amsterdam")
+	occurrences := calendar.Occurrences(time.Date(2026, 7, 6, 0, 0, 0, 0, amsterdam), time.Date(2026, 7, 27, 0, 0, 0, 0, amsterdam))
+	want := []struct {
+		start      time.Time
+		summary    string
+		overridden bool
+	}{
+		{time.Date(2026, 7, 6, 9, 0, 0, 0, amsterdam), "Weekly", false},
+		{time.Date(2026, 7, 8, 13, 0, 0, 0, amsterdam), "Lunch", false},
+		{time.Date(2026, 7, 21, 14, 0, 0, 0, amsterdam), "Moved", true},
+	}
+	if len(occurrences) != len(want) {
+		t.Fatalf("Expected %d occurrences, got %d", len(want), len(occurrences))
+	}
+	for i, o := range occurrences {
+		if !o.Start.Equal(want[i].start) || o.Event.Summary != want[i].summary || o.Overridden != want[i].overridden {
+			t.Errorf("Expected %s at %s, got %s at %s", want[i].summary, want[i].start, o.Event.Summary, o.Start)
+		}
+	}
+
+	weekly := occurrences[0]
+	if weekly.Start.Location().String() != "Europe/Amsterdam" || !weekly.End.Equal(time.Date(2026, 7, 6, 10, 0, 0, 0, amsterdam)) {
+		t.Errorf("Expected the instance to end at 10:00 in Amsterdam, got %s", weekly.End)
+	}
+	if weekly.Master.Summary != "Weekly" || !weekly.Master.Start.Equal(time.Date(2018, 7, 2, 9, 0, 0, 0, amsterdam)) {
+		t.Errorf("Expected the master event, got %s", weekly.Master)
+	}
+	if weekly.Index != 418 {
+		t.Errorf("Expected instance 418, got %d", weekly.Index)
+	}
+	moved := occurrences[2]
+	if moved.Master.Summary != "Weekly" || !moved.RecurrenceID.Equal(time.Date(2026, 7, 20, 9, 0, 0, 0, amsterdam)) {
+		t.Errorf("Expected the override of the 2026-07-20 instance, got %s", moved.RecurrenceID)
+	}
+}
+
+func TestGetEventsOnAndAt(t *testing.T) {
+	content := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:daily",
+		"DTSTART:20210705T090000Z",
+		"DTEND:20210705T091500Z",
+		"RRULE:FREQ=DAILY",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:monthly",
+		"DTSTART:20210705T140000Z",
+		"DTEND:20210705T160000Z",
+		"RRULE:FREQ=MONTHLY;BYDAY=1MO",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:yearly",
+		"DTSTART;VALUE=DATE:20200802",
+		"DTEND;VALUE=DATE:20200803",
+		"RRULE:FREQ=YEARLY",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:single",
+		"DTSTART:20210802T120000Z",
+		"DTEND:20210802T130000Z",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+	calendar := parseTestCalendar(t, content)
+
+	uids := func(events []*Event) string {
+		ids := []string{}
+		for _, event := range events {
+			ids = append(ids, event.ImportedID)
+		}
+		return strings.Join(ids, ",")
+	}
+
+	days := []struct {
+		date time.Time
+		uids string
+	}{
+		{time.Date(2021, 8, 2, 0, 0, 0, 0, time.UTC), "yearly,daily,single,monthly"},
+		{time.Date(2021, 8, 3, 23, 0, 0, 0, time.UTC), "daily"},
+		{time.Date(2021, 7, 4, 0, 0, 0, 0, time.UTC), ""},
+	}
+	for _, d := range days {
+		if events := uids(calendar.GetEventsOn(d.date)); events != d.uids {
+			t.Errorf("Expected events %q on %s, got %q", d.uids, d.date, events)
+		}
+	}
+
+	instants := []struct {
+		instant time.Time
+		uids    string
+	}{
+		{time.Date(2021, 8, 2, 0, 0, 0, 0, time.UTC), "yearly"},
+		{time.Date(2021, 8, 2, 9, 0, 0, 0, time.UTC), "yearly,daily"},
+		{time.Date(2021, 8, 2, 9, 15, 0, 0, time.UTC), "yearly"},
+		{time.Date(2021, 8, 2, 15, 0, 0, 0, time.UTC), "yearly,monthly"},
+		{time.Date(2021, 8, 3, 9, 10, 0, 0, time.UTC), "daily"},
+		{time.Date(2021, 8, 3, 15, 0, 0, 0, time.UTC), ""},
+	}
+	for _, i := range instants {
+		if events := uids(calendar.GetEventsAt(i.instant)); events != i.uids {
+			t.Errorf("Expected events %q at %s, got %q", i.uids, i.instant, events)
+		}
+	}
+}
+
+func TestOccurrencesOfMovedInstances(t *testing.T) {
+	content := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:weekly",
+		"SUMMARY:Weekly",
+		"DTSTART:20260706T090000Z",
+		"DTEND:20260706T100000Z",
+		"RRULE:FREQ=WEEKLY",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:weekly",
+		"SUMMARY:Earlier",
+		"RECURRENCE-ID:20260720T090000Z",
+		"DTSTART:20260708T090000Z",
+		"DTEND:20260708T100000Z",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:weekly",
+		"SUMMARY:Later",
+		"RECURRENCE-ID:20260706T090000Z",
+		"DTSTART:20260709T090000Z",
+		"DTEND:20260709T100000Z",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+	calendar := parseTestCalendar(t, content)
+
+	occurrences := calendar.Occurrences(time.Date(2026, 7, 8, 0, 0, 0, 0, time.UTC), time.Date(2026, 7, 10, 0, 0, 0, 0, time.UTC))
+	if len(occurrences) != 2 {
+		t.Fatalf("Expected 2 occurrences, got %d", len(occurrences))
+	}
+	if occurrences[0].Event.Summary != "Earlier" || occurrences[0].Index != 2 {
+		t.Errorf("Expected the instance 2 that moved earlier, got %s (%d)", occurrences[0].Event.Summary, occurrences[0].Index)
+	}
+	if occurrences[1].Event.Summary != "Later" || occurrences[1].Index != 0 {
+		t.Errorf("Expected the instance 0 that moved later, got %s (%d)", occurrences[1].Event.Summary, occurrences[1].Index)
+	}
+}
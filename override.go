@@ -0,0 +1,140 @@
+package icalendar
+
+import (
+	"time"
+
+	"github.com/jurgen-kluft/go-icloud-calendar/rrule"
+)
+
+// masterOf returns the recurring event of which a RANGE=THISANDFUTURE override without
+// its own RRULE continues the instances, nil for any other event
+func (c *Calendar) masterOf(event *Event) *Event {
+	if !event.ThisAndFuture || event.Rrule != "" || event.ImportedID == "" {
+		return nil
+	}
+	i, ok := c.EventsByImportedID[event.ImportedID]
+	if !ok {
+		return nil
+	}
+	master := c.Events[i]
+	if !master.RecurrenceID.IsZero() || (master.Rrule == "" && len(master.RDates) == 0) {
+		return nil
+	}
+	return master
+}
+
+// insertFutureOverrides turns the RANGE=THISANDFUTURE overrides that were inserted before
+// their recurring event into recurring events
+func (c *Calendar) insertFutureOverrides(master *Event) error {
+	for _, i := range c.OverridesByUID[master.ImportedID] {
+		if c.masterOf(c.Events[i]) == nil || c.isRecurring(i) {
+			continue
+		}
+		c.unindexDates(i)
+		if err := c.insertRecurring(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isRecurring returns true when the event is one of the recurring events
+func (c *Calendar) isRecurring(eventRef Index) bool {
+	_, ok := c.RecurringEventsByRef[eventRef]
+	return ok
+}
+
+// newFutureSet creates the recurrence set of a RANGE=THISANDFUTURE override, it continues
+// the recurrence of the master from the override on, shifted by the time the override moved
+func newFutureSet(master *Event, override *Event) (*rrule.Set, error) {
+	offset := override.Start.Sub(override.RecurrenceID)
+	set := &rrule.Set{}
+	set.DTStart(override.Start)
+	if master.Rrule != "" {
+		option, err := rrule.StrToROptionInLocation(master.Rrule, master.Start.Location())
+		if err != nil {
+			return nil, err
+		}
+		option.Dtstart = override.Start
+		if option.Count != 0 {
+			// the occurrences before the override are used up
+			masterSet, err := newEventSet(master)
+			if err != nil {
+				return nil, err
+			}
+			next := masterSet.Iterator()
+			for value, ok := next(); ok && value.Before(override.RecurrenceID); value, ok = next() {
+				option.Count--
+			}
+			if option.Count < 1 {
+				option.Count = 1
+			}
+		}
+		rule, err := rrule.NewRRule(*option)
+		if err != nil {
+			return nil, err
+		}
+		set.RRule(rule)
+	} else {
+		set.RDate(override.Start)
+	}
+	for _, rdate := range master.RDates {
+		if !rdate.Before(override.RecurrenceID) {
+			set.RDate(rdate.Add(offset))
+		}
+	}
+	for _, value := range master.ExRules {
+		rule, err := newEventRule(override, value)
+		if err != nil {
+			return nil, err
+		}
+		set.ExRule(rule)
+	}
+	for _, exdate := range master.ExDates {
+		if !exdate.Before(override.RecurrenceID) {
+			set.ExDate(exdate.Add(offset))
+		}
+	}
+	return set, nil
+}
+
+// isOverridden returns true when the instance of the recurring event at the time is replaced
+// by an override, either one with the same RECURRENCE-ID or a later RANGE=THISANDFUTURE one
+func (c *Calendar) isOverridden(event *Event, set *rrule.Set, dateTime time.Time) bool {
+	if event.ImportedID == "" || len(c.OverridesByUID[event.ImportedID]) == 0 {
+		return false
+	}
+	start, ok := set.OccurrenceAt(dateTime)
+	if !ok {
+		return false
+	}
+	override, superseded := c.overrideOf(event, start)
+	return override != nil || superseded
+}
+
+// overrideOf returns the override that replaces the instance of the recurring event that
+// starts at start, superseded is true when a later RANGE=THISANDFUTURE override continues
+// the recurrence from the instance on
+func (c *Calendar) overrideOf(event *Event, start time.Time) (override *Event, superseded bool) {
+	// the RECURRENCE-ID of the instance is the start it had in the original recurrence
+	original := c.originalOf(event, start)
+	for _, i := range c.OverridesByUID[event.ImportedID] {
+		o := c.Events[i]
+		if o == event {
+			continue
+		}
+		if o.ThisAndFuture && o.RecurrenceID.After(event.RecurrenceID) && !original.Before(o.RecurrenceID) {
+			if o.RecurrenceID.Equal(original) && !c.isRecurring(i) {
+				// without its recurring event the override is a single instance
+				return o, false
+			}
+			superseded = true
+		} else if o.RecurrenceID.Equal(original) {
+			override = o
+		}
+	}
+	if superseded {
+		return nil, true
+	}
+	return override, false
+}
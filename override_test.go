@@ -0,0 +1,65 @@
+package icalendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecurrenceIDOverrides(t *testing.T) {
+	content := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:weekly",
+		"SUMMARY:Later",
+		"RECURRENCE-ID;RANGE=THISANDFUTURE:20210719T090000Z",
+		"DTSTART:20210719T100000Z",
+		"DTEND:20210719T110000Z",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:weekly",
+		"SUMMARY:Weekly",
+		"DTSTART:20210705T090000Z",
+		"DTEND:20210705T100000Z",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:weekly",
+		"SUMMARY:Moved",
+		"RECURRENCE-ID:20210712T090000Z",
+		"DTSTART:20210713T110000Z",
+		"DTEND:20210713T120000Z",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+	calendar := parseTestCalendar(t, content)
+
+	cases := []struct {
+		date    time.Time
+		summary string
+	}{
+		{time.Date(2021, 7, 5, 9, 30, 0, 0, time.UTC), "Weekly"},
+		{time.Date(2021, 7, 12, 9, 30, 0, 0, time.UTC), ""},
+		{time.Date(2021, 7, 13, 11, 30, 0, 0, time.UTC), "Moved"},
+		{time.Date(2021, 7, 19, 9, 30, 0, 0, time.UTC), ""},
+		{time.Date(2021, 7, 19, 10, 30, 0, 0, time.UTC), "Later"},
+		{time.Date(2021, 7, 26, 9, 30, 0, 0, time.UTC), ""},
+		{time.Date(2021, 7, 26, 10, 30, 0, 0, time.UTC), "Later"},
+	}
+	for _, c := range cases {
+		events := calendar.GetEventsFor(c.date)
+		if c.summary == "" && len(events) != 0 {
+			t.Errorf("Expected no events on %s, got %s", c.date, events[0].Summary)
+		} else if c.summary != "" && (len(events) != 1 || events[0].Summary != c.summary) {
+			t.Errorf("Expected %s on %s, got %d events", c.summary, c.date, len(events))
+		}
+	}
+
+	ievent, _ := calendar.GetEventIndexByImportedID("weekly")
+	if event, _ := calendar.GetEventByIndex(ievent); event.Summary != "Weekly" {
+		t.Errorf("Expected the UID to refer to the recurring event, found %s", event.Summary)
+	}
+	if len(calendar.OverridesByUID["weekly"]) != 2 {
+		t.Errorf("Expected 2 overrides, found %d", len(calendar.OverridesByUID["weekly"]))
+	}
+}
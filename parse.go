@@ -2,34 +2,69 @@ package icalendar
 
 import (
 	"fmt"
-	"regexp"
 	"strconv"
 	"strings"
 	"time"
 )
 
+// ParseOptions are the options that control how a calendar is parsed
+type ParseOptions struct {
+	RepeatRuleApply bool      // RepeatRuleApply is true , the rrule will create new objects for the repeated events
+	MaxRepeats      int       // MaxRepeats max of the rrule repeat for single event, 0 for no maximum
+	RepeatUntil     time.Time // RepeatUntil is the horizon of the repeated events, zero for no horizon
+}
+
+// DefaultMaxRepeats is the maximum number of repeated events for a single event when
+// neither a maximum nor a horizon is given
+const DefaultMaxRepeats = 10
+
+// DefaultParseOptions returns the options that are used when none are set, recurring
+// events are not repeated into new objects
+func DefaultParseOptions() ParseOptions {
+	return ParseOptions{RepeatRuleApply: false, MaxRepeats: DefaultMaxRepeats}
+}
+
 type parser struct {
 	reader          Reader
-	repeatRuleApply bool // RepeatRuleApply is true , the rrule will create new objects for the repeated events
-	maxRepeats      int  // MaxRepeats max of the rrule repeat for single event
+	repeatRuleApply bool      // RepeatRuleApply is true , the rrule will create new objects for the repeated events
+	maxRepeats      int       // MaxRepeats max of the rrule repeat for single event
+	repeatUntil     time.Time // RepeatUntil is the horizon of the repeated events
 	errorsOccured   []error
 	parsedEvents    []*Event
+	locations       map[string]*time.Location // locations compiled from the VTIMEZONE components
+	floating        *time.Location            // location of date-time values without TZID or Z
 }
 
 // creates new parser
 func createParser(r Reader) *parser {
 	p := new(parser)
 	p.reader = r
-	p.repeatRuleApply = false
-	p.maxRepeats = 10
+	p.setOptions(DefaultParseOptions())
 	p.errorsOccured = []error{}
 	p.parsedEvents = []*Event{}
+	p.locations = map[string]*time.Location{}
+	p.floating = time.Local
 	return p
 }
 
+func (p *parser) setOptions(options ParseOptions) {
+	p.repeatRuleApply = options.RepeatRuleApply
+	p.maxRepeats = options.MaxRepeats
+	p.repeatUntil = options.RepeatUntil
+	if p.maxRepeats <= 0 && p.repeatUntil.IsZero() {
+		p.maxRepeats = DefaultMaxRepeats
+	}
+}
+
+func (p *parser) options() ParseOptions {
+	return ParseOptions{RepeatRuleApply: p.repeatRuleApply, MaxRepeats: p.maxRepeats, RepeatUntil: p.repeatUntil}
+}
+
 func (p *parser) reset() {
 	p.errorsOccured = []error{}
 	p.parsedEvents = []*Event{}
+	p.locations = map[string]*time.Location{}
+	p.floating = time.Local
 }
 
 func (p *parser) read(cal *Calendar) error {
@@ -59,75 +94,135 @@ func (p *parser) getErrors() []error {
 // PARSING
 
 func (p *parser) parseContent(ical *Calendar, content string) {
-	// split the data into calendar info and events data
-	eventsData, calInfo := explodeICal(content)
+	// build the component tree from the content lines
+	components, err := decodeComponents(newLexer(strings.NewReader(content)))
+	if err != nil {
+		p.errorsOccured = append(p.errorsOccured, err)
+	}
+
+	var calInfo *Component
+	for _, c := range components {
+		if c.Name == "VCALENDAR" {
+			calInfo = c
+			break
+		}
+	}
+	if calInfo == nil {
+		p.errorsOccured = append(p.errorsOccured, fmt.Errorf("Content has no VCALENDAR component"))
+		return
+	}
 
 	// set the calendar properties
+	ical.Component = calInfo
 	ical.Name = (p.parseICalName(calInfo))
 	ical.Description = (p.parseICalDesc(calInfo))
 	ical.Version = (p.parseICalVersion(calInfo))
+
+	// timezones are needed to resolve the calendar timezone and the date-time values of the events
+	p.parseTimezones(calInfo)
 	ical.Timezone = (p.parseICalTimezone(calInfo))
+	p.floating = ical.Timezone
 
 	// parse all events and add them to the calendar
-	p.parseEvents(ical, eventsData)
+	p.parseEvents(ical, calInfo.GetComponents("VEVENT"))
+
+	// parse all tasks and add them to the calendar
+	p.parseTodos(ical, calInfo.GetComponents("VTODO"))
+
+	// create new objects for the repeated events
+	if p.repeatRuleApply {
+		p.applyRepeatRules(ical)
+	}
 }
 
-func explodeICal(content string) ([]string, string) {
-	reEvents, _ := regexp.Compile(`(BEGIN:VEVENT(.*\n)*?END:VEVENT\r?\n)`)
-	allEvents := reEvents.FindAllString(content, len(content))
-	calInfo := reEvents.ReplaceAllString(content, "")
-	return allEvents, calInfo
+// applyRepeatRules inserts a copy of every instance of the recurring events, up to the maximum
+// number of repeats and the horizon, the copies are single events that override the instance
+func (p *parser) applyRepeatRules(cal *Calendar) {
+	recurring := len(cal.RecurringEvents)
+	for i := 0; i < recurring; i++ {
+		event := cal.Events[cal.RecurringEvents[i]]
+		duration := event.End.Sub(event.Start)
+		next := cal.RecurringEventRules[i].Iterator()
+		repeats := 0
+		for start, ok := next(); ok; start, ok = next() {
+			if p.maxRepeats > 0 && repeats >= p.maxRepeats || !p.repeatUntil.IsZero() && start.After(p.repeatUntil) {
+				break
+			}
+			repeats++
+			if override, superseded := cal.overrideOf(event, start); override != nil || superseded {
+				continue
+			}
+
+			repeated := *event
+			repeated.Start = start
+			repeated.End = start.Add(duration)
+			repeated.RecurrenceID = start
+			if !event.RecurrenceID.IsZero() {
+				repeated.RecurrenceID = start.Add(event.RecurrenceID.Sub(event.Start))
+			}
+			repeated.RecurrenceIDKind = event.StartKind
+			repeated.ThisAndFuture = false
+			repeated.Rrule = ""
+			repeated.ExRules = nil
+			repeated.ExDates = nil
+			repeated.RDates = nil
+			repeated.ID = repeated.GenerateUUID()
+
+			if err := cal.InsertEvent(&repeated); err != nil {
+				p.errorsOccured = append(p.errorsOccured, err)
+			}
+		}
+	}
 }
 
-func (p *parser) parseICalName(content string) string {
-	re, _ := regexp.Compile(`X-WR-CALNAME:.*?\n`)
-	result := re.FindString(content)
-	return trimField(result, "X-WR-CALNAME:")
+func (p *parser) parseICalName(calInfo *Component) string {
+	return calInfo.PropertyText("X-WR-CALNAME")
 }
 
-func (p *parser) parseICalDesc(content string) string {
-	re, _ := regexp.Compile(`X-WR-CALDESC:.*?\n`)
-	result := re.FindString(content)
-	return trimField(result, "X-WR-CALDESC:")
+func (p *parser) parseICalDesc(calInfo *Component) string {
+	return calInfo.PropertyText("X-WR-CALDESC")
 }
 
-func (p *parser) parseICalVersion(content string) float64 {
-	re, _ := regexp.Compile(`VERSION:.*?\n`)
-	result := re.FindString(content)
+func (p *parser) parseICalVersion(calInfo *Component) float64 {
 	// parse the version result to float
-	ver, _ := strconv.ParseFloat(trimField(result, "VERSION:"), 64)
+	ver, _ := strconv.ParseFloat(calInfo.PropertyValue("VERSION"), 64)
 	return ver
 }
 
-func (p *parser) parseICalTimezone(content string) *time.Location {
-	re, _ := regexp.Compile(`X-WR-TIMEZONE:.*?\n`)
-	result := re.FindString(content)
-
+func (p *parser) parseICalTimezone(calInfo *Component) *time.Location {
 	// parse the timezone result to time.Location
-	timezone := trimField(result, "X-WR-TIMEZONE:")
-	// create location instance
-	loc, err := time.LoadLocation(timezone)
+	timezone := calInfo.PropertyValue("X-WR-TIMEZONE")
+	if timezone == "" {
+		return time.UTC
+	}
+	// resolve it like a TZID, also custom and Windows timezone names
+	loc, err := p.location(timezone)
 
-	// if fails with the timezone => go Local
+	// if fails with the timezone => go UTC
 	if err != nil {
 		p.errorsOccured = append(p.errorsOccured, err)
-		loc, _ = time.LoadLocation("UTC")
+		loc = time.UTC
 	}
 	return loc
 }
 
 // EVENTS PARSING
 
-func (p *parser) parseEvents(cal *Calendar, eventsData []string) {
+func (p *parser) parseEvents(cal *Calendar, eventsData []*Component) {
 	for _, eventData := range eventsData {
 		event := NewEvent()
 
-		start := p.parseEventStart(eventData)
-		end := p.parseEventEnd(eventData)
-		recurrence := p.parseEventRecurrence(eventData)
+		start, startKind := p.parseEventStart(eventData)
+		end, endKind := p.parseEventEnd(eventData)
+		duration := p.parseEventDuration(eventData)
+		if duration != nil && eventData.GetProperty("DTEND") == nil {
+			// the end is given as a duration from the start
+			end, endKind = duration.AddTo(start), startKind
+		}
+		recurrence, recurrenceKind := p.parseEventRecurrence(eventData)
 
-		// whole day event when both times are 00:00:00
-		wholeDay := start.Hour() == 0 && end.Hour() == 0 && start.Minute() == 0 && end.Minute() == 0 && start.Second() == 0 && end.Second() == 0
+		// whole day event when the start is a date (VALUE=DATE)
+		wholeDay := startKind == DateValue
 
 		event.Status = (p.parseEventStatus(eventData))
 		event.Summary = (p.parseEventSummary(eventData))
@@ -138,15 +233,25 @@ func (p *parser) parseEvents(cal *Calendar, eventsData []string) {
 		event.Created = (p.parseEventCreated(eventData))
 		event.Modified = (p.parseEventModified(eventData))
 		event.Rrule = (p.parseEventRRule(eventData))
+		event.ExRules = (p.parseEventExRules(eventData))
 		event.Location = (p.parseEventLocation(eventData))
+		event.Categories = (p.parseEventCategories(eventData))
 		event.Geo = (p.parseEventGeo(eventData))
 		event.Start = (start)
+		event.StartKind = (startKind)
 		event.End = (end)
+		event.EndKind = (endKind)
+		event.Duration = (duration)
 		event.RecurrenceID = (recurrence)
+		event.RecurrenceIDKind = (recurrenceKind)
+		event.ThisAndFuture = (p.parseEventRecurrenceRange(eventData))
+		event.ExDates = (p.parseEventExDates(eventData))
+		event.RDates = (p.parseEventRDates(eventData))
 		event.IsWholeDayEvent = (wholeDay)
 		event.Attendees = (p.parseEventAttendees(eventData))
 		event.Organizer = (p.parseEventOrganizer(eventData))
 		event.Owner = (cal)
+		event.Component = (eventData)
 		event.ID = (event.GenerateUUID())
 
 		err := cal.InsertEvent(event)
@@ -156,111 +261,178 @@ func (p *parser) parseEvents(cal *Calendar, eventsData []string) {
 	}
 }
 
-func (p *parser) parseEventSummary(eventData string) string {
-	re, _ := regexp.Compile(`SUMMARY:.*?\n`)
-	result := re.FindString(eventData)
-	return trimField(result, "SUMMARY:")
+func (p *parser) parseEventSummary(eventData *Component) string {
+	return eventData.PropertyText("SUMMARY")
 }
 
-func (p *parser) parseEventStatus(eventData string) string {
-	re, _ := regexp.Compile(`STATUS:.*?\n`)
-	result := re.FindString(eventData)
-	return trimField(result, "STATUS:")
+func (p *parser) parseEventStatus(eventData *Component) string {
+	return eventData.PropertyText("STATUS")
 }
 
-func (p *parser) parseEventDescription(eventData string) string {
-	re, _ := regexp.Compile(`DESCRIPTION:.*?\n(?:\s+.*?\n)*`)
-	result := re.FindString(eventData)
-	return trimField(strings.Replace(result, "\r\n ", "", -1), "DESCRIPTION:")
+func (p *parser) parseEventDescription(eventData *Component) string {
+	return eventData.PropertyText("DESCRIPTION")
 }
 
-func (p *parser) parseEventID(eventData string) string {
-	re, _ := regexp.Compile(`UID:.*?\n`)
-	result := re.FindString(eventData)
-	return trimField(result, "UID:")
+func (p *parser) parseEventID(eventData *Component) string {
+	return eventData.PropertyText("UID")
 }
 
-func (p *parser) parseEventClass(eventData string) string {
-	re, _ := regexp.Compile(`CLASS:.*?\n`)
-	result := re.FindString(eventData)
-	return trimField(result, "CLASS:")
+func (p *parser) parseEventClass(eventData *Component) string {
+	return eventData.PropertyText("CLASS")
 }
 
-func (p *parser) parseEventSequence(eventData string) int {
-	re, _ := regexp.Compile(`SEQUENCE:.*?\n`)
-	result := re.FindString(eventData)
-	sq, _ := strconv.Atoi(trimField(result, "SEQUENCE:"))
+func (p *parser) parseEventSequence(eventData *Component) int {
+	sq, _ := strconv.Atoi(eventData.PropertyValue("SEQUENCE"))
 	return sq
 }
 
-func (p *parser) parseEventCreated(eventData string) time.Time {
-	re, _ := regexp.Compile(`CREATED:.*?\n`)
-	result := re.FindString(eventData)
-	created := trimField(result, "CREATED:")
-	t, _ := time.Parse(IcsFormat, created)
+func (p *parser) parseEventCreated(eventData *Component) time.Time {
+	t, _ := time.Parse(IcsFormat, eventData.PropertyValue("CREATED"))
 	return t
 }
 
-func (p *parser) parseEventModified(eventData string) time.Time {
-	re, _ := regexp.Compile(`LAST-MODIFIED:.*?\n`)
-	result := re.FindString(eventData)
-	modified := trimField(result, "LAST-MODIFIED:")
-	t, _ := time.Parse(IcsFormat, modified)
+func (p *parser) parseEventModified(eventData *Component) time.Time {
+	t, _ := time.Parse(IcsFormat, eventData.PropertyValue("LAST-MODIFIED"))
 	return t
 }
 
-func parseEventTime(eventName string, eventData string) time.Time {
-	reWholeDay, _ := regexp.Compile(eventName + `;VALUE=DATE:.*?\n`)
-	re, _ := regexp.Compile(eventName + `(;TZID=.*?){0,1}:.*?\n`)
-	resultWholeDay := reWholeDay.FindString(eventData)
-	var t time.Time
-	if resultWholeDay != "" {
-		// whole day event
-		modified := trimField(resultWholeDay, eventName+";VALUE=DATE:")
-		t, _ = time.Parse(IcsFormatWholeDay, modified)
-	} else {
-		// event that has end hour and minute
-		result := re.FindString(eventData)
-		modified := trimField(result, eventName+"(;TZID=.*?){0,1}:")
-
-		if !strings.Contains(modified, "Z") {
-			modified = fmt.Sprintf("%sZ", modified)
+// parseEventTime parses the first date or date-time value of a property, a TZID
+// parameter is resolved using the VTIMEZONE components or the IANA database,
+// values without TZID or Z are floating and resolved in the calendar timezone
+func (p *parser) parseEventTime(eventName string, eventData *Component) (time.Time, TimeKind) {
+	prop := eventData.GetProperty(eventName)
+	if prop == nil {
+		return time.Time{}, ZonedTime
+	}
+	times, kind := p.parseDateTimes(prop)
+	if len(times) == 0 {
+		return time.Time{}, kind
+	}
+	return times[0], kind
+}
+
+// parseDateTimes parses all the values of a (multi-valued) date or date-time
+// property like EXDATE and RDATE, for PERIOD values the start is returned, the
+// kind of the first value is returned
+func (p *parser) parseDateTimes(prop *Property) ([]time.Time, TimeKind) {
+	loc := p.floating
+	tzid := prop.ParamValue("TZID")
+	if tzid != "" {
+		var err error
+		if loc, err = p.location(tzid); err != nil {
+			p.errorsOccured = append(p.errorsOccured, fmt.Errorf("%s: %s", prop.Name, err))
+			loc = p.floating
 		}
-		t, _ = time.Parse(IcsFormat, modified)
 	}
-	return t
+
+	times := []time.Time{}
+	kinds := []TimeKind{}
+	for _, value := range strings.Split(prop.Value, ",") {
+		if i := strings.Index(value, "/"); i >= 0 {
+			// period of time, only the start is used
+			value = value[:i]
+		}
+
+		var t time.Time
+		var kind TimeKind
+		var err error
+		if prop.ParamValue("VALUE") == "DATE" || len(value) == len(IcsFormatWholeDay) {
+			// whole day event
+			t, err = time.ParseInLocation(IcsFormatWholeDay, value, loc)
+			kind = DateValue
+		} else if strings.HasSuffix(value, "Z") {
+			t, err = time.Parse(IcsFormat, value)
+			kind = UTCTime
+		} else if tzid != "" {
+			t, err = time.ParseInLocation(IcsFormatLocal, value, loc)
+			kind = ZonedTime
+		} else {
+			t, err = time.ParseInLocation(IcsFormatLocal, value, loc)
+			kind = FloatingTime
+		}
+		if err != nil {
+			p.errorsOccured = append(p.errorsOccured, fmt.Errorf("%s has an invalid value '%s'", prop.Name, value))
+			continue
+		}
+		times = append(times, t)
+		kinds = append(kinds, kind)
+	}
+	if len(kinds) == 0 {
+		return times, ZonedTime
+	}
+	return times, kinds[0]
 }
 
-func (p *parser) parseEventStart(eventData string) time.Time {
-	return parseEventTime("DTSTART", eventData)
+func (p *parser) parseEventStart(eventData *Component) (time.Time, TimeKind) {
+	return p.parseEventTime("DTSTART", eventData)
 }
 
-func (p *parser) parseEventEnd(eventData string) time.Time {
-	return parseEventTime("DTEND", eventData)
+func (p *parser) parseEventEnd(eventData *Component) (time.Time, TimeKind) {
+	return p.parseEventTime("DTEND", eventData)
 }
 
-func (p *parser) parseEventRecurrence(eventData string) time.Time {
-	return parseEventTime("RECURRENCE-ID", eventData)
+func (p *parser) parseEventRecurrence(eventData *Component) (time.Time, TimeKind) {
+	return p.parseEventTime("RECURRENCE-ID", eventData)
 }
 
-func (p *parser) parseEventRRule(eventData string) string {
-	re, _ := regexp.Compile(`RRULE:.*?\n`)
-	result := re.FindString(eventData)
-	return trimField(result, "RRULE:")
+func (p *parser) parseEventRecurrenceRange(eventData *Component) bool {
+	prop := eventData.GetProperty("RECURRENCE-ID")
+	return prop != nil && strings.ToUpper(prop.ParamValue("RANGE")) == "THISANDFUTURE"
 }
 
-func (p *parser) parseEventLocation(eventData string) string {
-	re, _ := regexp.Compile(`LOCATION:.*?\n`)
-	result := re.FindString(eventData)
-	return trimField(result, "LOCATION:")
+func (p *parser) parseEventDuration(eventData *Component) *Duration {
+	value := eventData.PropertyValue("DURATION")
+	if value == "" {
+		return nil
+	}
+	d, err := ParseDuration(value)
+	if err != nil {
+		p.errorsOccured = append(p.errorsOccured, err)
+		return nil
+	}
+	return d
 }
 
-func (p *parser) parseEventGeo(eventData string) *Geo {
-	re, _ := regexp.Compile(`GEO:.*?\n`)
-	result := re.FindString(eventData)
+func (p *parser) parseEventExDates(eventData *Component) []time.Time {
+	exdates := []time.Time{}
+	for _, prop := range eventData.GetProperties("EXDATE") {
+		times, _ := p.parseDateTimes(prop)
+		exdates = append(exdates, times...)
+	}
+	return exdates
+}
 
-	value := trimField(result, "GEO:")
-	values := strings.Split(value, ";")
+func (p *parser) parseEventRDates(eventData *Component) []time.Time {
+	rdates := []time.Time{}
+	for _, prop := range eventData.GetProperties("RDATE") {
+		times, _ := p.parseDateTimes(prop)
+		rdates = append(rdates, times...)
+	}
+	return rdates
+}
+
+func (p *parser) parseEventRRule(eventData *Component) string {
+	return eventData.PropertyValue("RRULE")
+}
+
+func (p *parser) parseEventExRules(eventData *Component) []string {
+	exrules := []string{}
+	for _, prop := range eventData.GetProperties("EXRULE") {
+		exrules = append(exrules, prop.Value)
+	}
+	return exrules
+}
+
+func (p *parser) parseEventLocation(eventData *Component) string {
+	return eventData.PropertyText("LOCATION")
+}
+
+func (p *parser) parseEventCategories(eventData *Component) []string {
+	return eventData.PropertyTextValues("CATEGORIES")
+}
+
+func (p *parser) parseEventGeo(eventData *Component) *Geo {
+	values := strings.Split(eventData.PropertyValue("GEO"), ";")
 	if len(values) < 2 {
 		return nil
 	}
@@ -268,18 +440,81 @@ func (p *parser) parseEventGeo(eventData string) *Geo {
 	return NewGeo(values[0], values[1])
 }
 
-// ATTENDEE PARSING
+// TODOS PARSING
 
-func (p *parser) parseEventAttendees(eventData string) []*Attendee {
-	attendeesObj := []*Attendee{}
-	re, _ := regexp.Compile(`ATTENDEE(:|;)(.*?\r?\n)(\s.*?\r?\n)*`)
-	attendees := re.FindAllString(eventData, len(eventData))
+func (p *parser) parseTodos(cal *Calendar, todosData []*Component) {
+	for _, todoData := range todosData {
+		todo := NewTodo()
 
-	for _, attendeeData := range attendees {
-		if attendeeData == "" {
-			continue
+		start, startKind := p.parseEventStart(todoData)
+		due, dueKind := p.parseTodoDue(todoData)
+		if duration := p.parseEventDuration(todoData); duration != nil && todoData.GetProperty("DUE") == nil {
+			// the task is due a duration after its start
+			due, dueKind = duration.AddTo(start), startKind
 		}
-		attendee := p.parseAttendee(strings.Replace(strings.Replace(attendeeData, "\r", "", 1), "\n ", "", 1))
+
+		todo.Start = (start)
+		todo.StartKind = (startKind)
+		todo.Due = (due)
+		todo.DueKind = (dueKind)
+		todo.Completed = (p.parseTodoCompleted(todoData))
+		todo.PercentComplete = (p.parseTodoPercentComplete(todoData))
+		todo.Priority = (p.parseTodoPriority(todoData))
+		todo.Status = (p.parseEventStatus(todoData))
+		todo.Summary = (p.parseEventSummary(todoData))
+		todo.Description = (p.parseEventDescription(todoData))
+		todo.ImportedID = (p.parseEventID(todoData))
+		todo.Class = (p.parseEventClass(todoData))
+		todo.Sequence = (p.parseEventSequence(todoData))
+		todo.Created = (p.parseEventCreated(todoData))
+		todo.Modified = (p.parseEventModified(todoData))
+		todo.Categories = (p.parseEventCategories(todoData))
+		todo.RelatedTo = (p.parseTodoRelatedTo(todoData))
+		todo.Rrule = (p.parseEventRRule(todoData))
+		todo.Owner = (cal)
+		todo.Component = (todoData)
+		todo.ID = (todo.GenerateUUID())
+
+		err := cal.InsertTodo(todo)
+		if err != nil {
+			p.errorsOccured = append(p.errorsOccured, err)
+		}
+	}
+}
+
+func (p *parser) parseTodoDue(todoData *Component) (time.Time, TimeKind) {
+	return p.parseEventTime("DUE", todoData)
+}
+
+func (p *parser) parseTodoCompleted(todoData *Component) time.Time {
+	t, _ := p.parseEventTime("COMPLETED", todoData)
+	return t
+}
+
+func (p *parser) parseTodoPercentComplete(todoData *Component) int {
+	percent, _ := strconv.Atoi(todoData.PropertyValue("PERCENT-COMPLETE"))
+	return percent
+}
+
+func (p *parser) parseTodoPriority(todoData *Component) int {
+	priority, _ := strconv.Atoi(todoData.PropertyValue("PRIORITY"))
+	return priority
+}
+
+func (p *parser) parseTodoRelatedTo(todoData *Component) []string {
+	related := []string{}
+	for _, prop := range todoData.GetProperties("RELATED-TO") {
+		related = append(related, prop.Text())
+	}
+	return related
+}
+
+// ATTENDEE PARSING
+
+func (p *parser) parseEventAttendees(eventData *Component) []*Attendee {
+	attendeesObj := []*Attendee{}
+	for _, attendeeData := range eventData.GetProperties("ATTENDEE") {
+		attendee := p.parseAttendee(attendeeData)
 		//  check for any fields set
 		if attendee.Email != "" || attendee.Name != "" || attendee.Role != "" || attendee.Status != "" || attendee.Type != "" {
 			attendeesObj = append(attendeesObj, attendee)
@@ -288,23 +523,20 @@ func (p *parser) parseEventAttendees(eventData string) []*Attendee {
 	return attendeesObj
 }
 
-func (p *parser) parseEventOrganizer(eventData string) *Attendee {
-
-	re, _ := regexp.Compile(`ORGANIZER(:|;)(.*?\r?\n)(\s.*?\r?\n)*`)
-	organizerData := re.FindString(eventData)
-	if organizerData == "" {
+func (p *parser) parseEventOrganizer(eventData *Component) *Attendee {
+	organizerData := eventData.GetProperty("ORGANIZER")
+	if organizerData == nil {
 		return nil
 	}
-	organizerDataFormated := strings.Replace(strings.Replace(organizerData, "\r", "", 1), "\n ", "", 1)
 
 	a := NewAttendee()
-	a.Email = (p.parseAttendeeMail(organizerDataFormated))
-	a.Name = (p.parseOrganizerName(organizerDataFormated))
+	a.Email = (p.parseAttendeeMail(organizerData))
+	a.Name = (p.parseAttendeeName(organizerData))
 
 	return a
 }
 
-func (p *parser) parseAttendee(attendeeData string) *Attendee {
+func (p *parser) parseAttendee(attendeeData *Property) *Attendee {
 
 	a := NewAttendee()
 	a.Email = (p.parseAttendeeMail(attendeeData))
@@ -315,55 +547,26 @@ func (p *parser) parseAttendee(attendeeData string) *Attendee {
 	return a
 }
 
-func (p *parser) parseAttendeeMail(attendeeData string) string {
-	re, _ := regexp.Compile(`mailto:.*?\n`)
-	result := re.FindString(attendeeData)
-	return trimField(result, "mailto:")
-}
-
-func (p *parser) parseAttendeeStatus(attendeeData string) string {
-	re, _ := regexp.Compile(`PARTSTAT=.*?;`)
-	result := re.FindString(attendeeData)
-	if result == "" {
-		return ""
+func (p *parser) parseAttendeeMail(attendeeData *Property) string {
+	value := attendeeData.Value
+	if strings.HasPrefix(strings.ToLower(value), "mailto:") {
+		return value[len("mailto:"):]
 	}
-	return trimField(result, `(PARTSTAT=|;)`)
+	return ""
 }
 
-func (p *parser) parseAttendeeRole(attendeeData string) string {
-	re, _ := regexp.Compile(`ROLE=.*?;`)
-	result := re.FindString(attendeeData)
-
-	if result == "" {
-		return ""
-	}
-	return trimField(result, `(ROLE=|;)`)
+func (p *parser) parseAttendeeStatus(attendeeData *Property) string {
+	return attendeeData.ParamValue("PARTSTAT")
 }
 
-func (p *parser) parseAttendeeName(attendeeData string) string {
-	re, _ := regexp.Compile(`CN=.*?;`)
-	result := re.FindString(attendeeData)
-	if result == "" {
-		return ""
-	}
-	return trimField(result, `(CN=|;)`)
+func (p *parser) parseAttendeeRole(attendeeData *Property) string {
+	return attendeeData.ParamValue("ROLE")
 }
 
-// parses the organizer Name
-func (p *parser) parseOrganizerName(orgData string) string {
-	re, _ := regexp.Compile(`CN=.*?:`)
-	result := re.FindString(orgData)
-	if result == "" {
-		return ""
-	}
-	return trimField(result, `(CN=|:)`)
+func (p *parser) parseAttendeeName(attendeeData *Property) string {
+	return attendeeData.ParamValue("CN")
 }
 
-func (p *parser) parseAttendeeType(attendeeData string) string {
-	re, _ := regexp.Compile(`CUTYPE=.*?;`)
-	result := re.FindString(attendeeData)
-	if result == "" {
-		return ""
-	}
-	return trimField(result, `(CUTYPE=|;)`)
+func (p *parser) parseAttendeeType(attendeeData *Property) string {
+	return attendeeData.ParamValue("CUTYPE")
 }
@@ -3,6 +3,7 @@ package icalendar
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -11,7 +12,7 @@ func TestLoadCalendar(t *testing.T) {
 	reader := readingFromFile("testCalendars/2eventsCal.ics")
 	parser := createParser(reader)
 
-	calendar := newCalendar()
+	calendar := newCalendar("")
 	err := parser.read(calendar)
 
 	if err != nil {
@@ -26,7 +27,7 @@ func TestLoadCalendar2(t *testing.T) {
 	reader := readingFromFile("testCalendars/4eventsWithRRule.ics")
 	parser := createParser(reader)
 
-	calendar := newCalendar()
+	calendar := newCalendar("")
 	err := parser.read(calendar)
 
 	if err != nil {
@@ -43,6 +44,36 @@ func TestLoadCalendar2(t *testing.T) {
 	}
 }
 
+func TestSeasonsCrossingNewYear(t *testing.T) {
+	reader := readingFromFile("testCalendars/4eventsWithRRule.ics")
+	parser := createParser(reader)
+	calendar := newCalendar("")
+	if err := parser.read(calendar); err != nil {
+		t.Error(err)
+	}
+
+	wants := []struct {
+		Time time.Time
+		Name string
+	}{
+		{Time: time.Date(2017, 12, 22, 12, 0, 0, 0, time.UTC), Name: "season=winter"},
+		{Time: time.Date(2019, 1, 15, 12, 0, 0, 0, time.UTC), Name: "season=winter"},
+		{Time: time.Date(2019, 3, 20, 12, 0, 0, 0, time.UTC), Name: "season=winter"},
+		{Time: time.Date(2019, 3, 21, 12, 0, 0, 0, time.UTC), Name: "season=spring"},
+		{Time: time.Date(2019, 9, 23, 12, 0, 0, 0, time.UTC), Name: "season=autumn"},
+		{Time: time.Date(2019, 12, 21, 12, 0, 0, 0, time.UTC), Name: "season=autumn"},
+		{Time: time.Date(2019, 12, 22, 12, 0, 0, 0, time.UTC), Name: "season=winter"},
+	}
+	for _, want := range wants {
+		events := calendar.GetEventsFor(want.Time)
+		if len(events) != 1 {
+			t.Errorf("Expected 1 event on %s, got %d", want.Time, len(events))
+		} else if events[0].Summary != want.Name {
+			t.Errorf("Expected %s on %s, got %s", want.Name, want.Time, events[0].Summary)
+		}
+	}
+}
+
 func TestNewParser(t *testing.T) {
 	reader := readingFromFile("testCalendars/2eventsCal.ics")
 	parser := createParser(reader)
@@ -55,7 +86,7 @@ func TestNewParser(t *testing.T) {
 func TestParsingNotExistingCalendar(t *testing.T) {
 	reader := readingFromFile("testCalendars/notFound.ics")
 	parser := createParser(reader)
-	calendar := newCalendar()
+	calendar := newCalendar("")
 	parser.read(calendar)
 	parseErrors := parser.getErrors()
 	if len(parseErrors) != 1 {
@@ -66,7 +97,7 @@ func TestParsingNotExistingCalendar(t *testing.T) {
 func TestParsingWrongCalendarUrls(t *testing.T) {
 	reader := readingFromURL("http://localhost/goTestFails")
 	parser := createParser(reader)
-	calendar := newCalendar()
+	calendar := newCalendar("")
 	err := parser.read(calendar)
 	parseErrors := parser.getErrors()
 
@@ -86,7 +117,7 @@ func TestParsingWrongCalendarUrls(t *testing.T) {
 func TestCalendarInfo(t *testing.T) {
 	reader := readingFromFile("testCalendars/2eventsCal.ics")
 	parser := createParser(reader)
-	calendar := newCalendar()
+	calendar := newCalendar("")
 	parser.read(calendar)
 	parseErrors := parser.getErrors()
 
@@ -140,7 +171,7 @@ func TestCalendarInfo(t *testing.T) {
 func TestCalendarEvents(t *testing.T) {
 	reader := readingFromFile("testCalendars/2eventsCal.ics")
 	parser := createParser(reader)
-	calendar := newCalendar()
+	calendar := newCalendar("")
 	parser.read(calendar)
 	parseErrors := parser.getErrors()
 	if len(parseErrors) != 0 {
@@ -155,13 +186,14 @@ func TestCalendarEvents(t *testing.T) {
 	event, err := calendar.GetEventByIndex(ievent)
 
 	//  event must have
-	start, _ := time.Parse(IcsFormat, "20140714T100000Z")
-	end, _ := time.Parse(IcsFormat, "20140714T110000Z")
+	sofia, _ := time.LoadLocation("Europe/Sofia")
+	start := time.Date(2014, 7, 14, 10, 0, 0, 0, sofia)
+	end := time.Date(2014, 7, 14, 11, 0, 0, 0, sofia)
 	created, _ := time.Parse(IcsFormat, "20140515T075711Z")
 	modified, _ := time.Parse(IcsFormat, "20141125T074253Z")
 	location := "In The Office"
 	geo := NewGeo("39.620511", "-75.852557")
-	desc := "1. Report on previous weekly tasks. \\n2. Plan of the present weekly tasks."
+	desc := "1. Report on previous weekly tasks. \n2. Plan of the present weekly tasks."
 	seq := 1
 	status := "CONFIRMED"
 	summary := "General Operative Meeting"
@@ -172,11 +204,11 @@ func TestCalendarEvents(t *testing.T) {
 	org.Name = ("r.chupetlovska@gmail.com")
 	org.Email = ("r.chupetlovska@gmail.com")
 
-	if event.Start != start {
+	if !event.Start.Equal(start) {
 		t.Errorf("Expected start %s, found %s", start, event.Start)
 	}
 
-	if event.End != end {
+	if !event.End.Equal(end) {
 		t.Errorf("Expected end %s, found %s", end, event.End)
 	}
 
@@ -254,7 +286,7 @@ func TestCalendarEvents(t *testing.T) {
 func TestCalendarEventAttendees(t *testing.T) {
 	reader := readingFromFile("testCalendars/2eventsCal.ics")
 	parser := createParser(reader)
-	calendar := newCalendar()
+	calendar := newCalendar("")
 	parser.read(calendar)
 	parseErrors := parser.getErrors()
 
@@ -328,7 +360,7 @@ func TestCalendarEventAttendees(t *testing.T) {
 func TestCalendarMultidayEvent(t *testing.T) {
 	reader := readingFromFile("testCalendars/multiday.ics")
 	parser := createParser(reader)
-	calendar := newCalendar()
+	calendar := newCalendar("")
 	err := parser.read(calendar)
 	parseErrors := parser.getErrors()
 
@@ -362,7 +394,7 @@ func TestLoadCalendar5(t *testing.T) {
 	reader := readingFromFile("testCalendars/5dailyEvents.ics")
 	parser := createParser(reader)
 
-	calendar := newCalendar()
+	calendar := newCalendar("")
 	err := parser.read(calendar)
 
 	if err != nil {
@@ -372,31 +404,33 @@ func TestLoadCalendar5(t *testing.T) {
 		}
 	}
 
-	now := time.Now()
+	// the times of the day are those of the zone of the events
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	now := time.Now().In(shanghai)
 
 	wants := []struct {
 		Time time.Time
 		Name string
 	}{
-		{Time: time.Date(now.Year(), now.Month(), now.Day(), 0, 10, 0, 0, now.Location()), Name: "timeofday=night"},
-		{Time: time.Date(now.Year(), now.Month(), now.Day(), 1, 10, 0, 0, now.Location()), Name: "timeofday=night"},
-		{Time: time.Date(now.Year(), now.Month(), now.Day(), 2, 10, 0, 0, now.Location()), Name: "timeofday=night"},
-		{Time: time.Date(now.Year(), now.Month(), now.Day(), 3, 10, 0, 0, now.Location()), Name: "timeofday=night"},
-		{Time: time.Date(now.Year(), now.Month(), now.Day(), 4, 10, 0, 0, now.Location()), Name: "timeofday=night"},
-		{Time: time.Date(now.Year(), now.Month(), now.Day(), 5, 10, 0, 0, now.Location()), Name: "timeofday=night"},
-		{Time: time.Date(now.Year(), now.Month(), now.Day(), 6, 10, 0, 0, now.Location()), Name: "timeofday=breakfast"},
-		{Time: time.Date(now.Year(), now.Month(), now.Day(), 7, 10, 0, 0, now.Location()), Name: "timeofday=breakfast"},
-		{Time: time.Date(now.Year(), now.Month(), now.Day(), 8, 10, 0, 0, now.Location()), Name: "timeofday=breakfast"},
-		{Time: time.Date(now.Year(), now.Month(), now.Day(), 9, 10, 0, 0, now.Location()), Name: "timeofday=morning"},
-		{Time: time.Date(now.Year(), now.Month(), now.Day(), 10, 10, 0, 0, now.Location()), Name: "timeofday=morning"},
-		{Time: time.Date(now.Year(), now.Month(), now.Day(), 11, 10, 0, 0, now.Location()), Name: "timeofday=morning"},
-		{Time: time.Date(now.Year(), now.Month(), now.Day(), 12, 10, 0, 0, now.Location()), Name: "timeofday=noon"},
-		{Time: time.Date(now.Year(), now.Month(), now.Day(), 13, 10, 0, 0, now.Location()), Name: "timeofday=afternoon"},
-		{Time: time.Date(now.Year(), now.Month(), now.Day(), 14, 10, 0, 0, now.Location()), Name: "timeofday=afternoon"},
-		{Time: time.Date(now.Year(), now.Month(), now.Day(), 15, 10, 0, 0, now.Location()), Name: "timeofday=afternoon"},
-		{Time: time.Date(now.Year(), now.Month(), now.Day(), 16, 10, 0, 0, now.Location()), Name: "timeofday=afternoon"},
-		{Time: time.Date(now.Year(), now.Month(), now.Day(), 17, 10, 0, 0, now.Location()), Name: "timeofday=afternoon"},
-		{Time: time.Date(now.Year(), now.Month(), now.Day(), 18, 10, 0, 0, now.Location()), Name: "timeofday=evening"},
+		{Time: time.Date(now.Year(), now.Month(), now.Day(), 0, 10, 0, 0, shanghai), Name: "timeofday=night"},
+		{Time: time.Date(now.Year(), now.Month(), now.Day(), 1, 10, 0, 0, shanghai), Name: "timeofday=night"},
+		{Time: time.Date(now.Year(), now.Month(), now.Day(), 2, 10, 0, 0, shanghai), Name: "timeofday=night"},
+		{Time: time.Date(now.Year(), now.Month(), now.Day(), 3, 10, 0, 0, shanghai), Name: "timeofday=night"},
+		{Time: time.Date(now.Year(), now.Month(), now.Day(), 4, 10, 0, 0, shanghai), Name: "timeofday=night"},
+		{Time: time.Date(now.Year(), now.Month(), now.Day(), 5, 10, 0, 0, shanghai), Name: "timeofday=night"},
+		{Time: time.Date(now.Year(), now.Month(), now.Day(), 6, 10, 0, 0, shanghai), Name: "timeofday=breakfast"},
+		{Time: time.Date(now.Year(), now.Month(), now.Day(), 7, 10, 0, 0, shanghai), Name: "timeofday=breakfast"},
+		{Time: time.Date(now.Year(), now.Month(), now.Day(), 8, 10, 0, 0, shanghai), Name: "timeofday=breakfast"},
+		{Time: time.Date(now.Year(), now.Month(), now.Day(), 9, 10, 0, 0, shanghai), Name: "timeofday=morning"},
+		{Time: time.Date(now.Year(), now.Month(), now.Day(), 10, 10, 0, 0, shanghai), Name: "timeofday=morning"},
+		{Time: time.Date(now.Year(), now.Month(), now.Day(), 11, 10, 0, 0, shanghai), Name: "timeofday=morning"},
+		{Time: time.Date(now.Year(), now.Month(), now.Day(), 12, 10, 0, 0, shanghai), Name: "timeofday=noon"},
+		{Time: time.Date(now.Year(), now.Month(), now.Day(), 13, 10, 0, 0, shanghai), Name: "timeofday=afternoon"},
+		{Time: time.Date(now.Year(), now.Month(), now.Day(), 14, 10, 0, 0, shanghai), Name: "timeofday=afternoon"},
+		{Time: time.Date(now.Year(), now.Month(), now.Day(), 15, 10, 0, 0, shanghai), Name: "timeofday=afternoon"},
+		{Time: time.Date(now.Year(), now.Month(), now.Day(), 16, 10, 0, 0, shanghai), Name: "timeofday=afternoon"},
+		{Time: time.Date(now.Year(), now.Month(), now.Day(), 17, 10, 0, 0, shanghai), Name: "timeofday=afternoon"},
+		{Time: time.Date(now.Year(), now.Month(), now.Day(), 18, 10, 0, 0, shanghai), Name: "timeofday=evening"},
 	}
 
 	for _, want := range wants {
@@ -414,3 +448,125 @@ func TestLoadCalendar5(t *testing.T) {
 		}
 	}
 }
+
+func TestWeeklyEventsFor(t *testing.T) {
+	content := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:standup",
+		"SUMMARY:Standup",
+		"DTSTART:20210705T090000Z",
+		"DTEND:20210705T091500Z",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+	calendar := parseTestCalendar(t, content)
+
+	if events := calendar.GetEventsFor(time.Date(2021, 7, 14, 9, 5, 0, 0, time.UTC)); len(events) != 1 || events[0].Summary != "Standup" {
+		t.Errorf("Expected the standup on a Wednesday, got %d events", len(events))
+	}
+	if events := calendar.GetEventsFor(time.Date(2021, 7, 15, 9, 5, 0, 0, time.UTC)); len(events) != 0 {
+		t.Errorf("Expected no standup on a Thursday, got %d events", len(events))
+	}
+}
+
+func TestRecurringEventExceptions(t *testing.T) {
+	content := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:weekly",
+		"SUMMARY:Weekly meeting",
+		"DTSTART:20210705T140000Z",
+		"DTEND:20210705T150000Z",
+		"RRULE:FREQ=WEEKLY",
+		"EXDATE:20210712T140000Z",
+		"RDATE:20210715T140000Z",
+		"EXRULE:FREQ=YEARLY;BYMONTH=7;BYDAY=-1MO",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+	calendar := parseTestCalendar(t, content)
+
+	cases := []struct {
+		date  time.Time
+		count int
+	}{
+		{time.Date(2021, 7, 5, 14, 30, 0, 0, time.UTC), 1},
+		{time.Date(2021, 7, 12, 14, 30, 0, 0, time.UTC), 0},
+		{time.Date(2021, 7, 15, 14, 30, 0, 0, time.UTC), 1},
+		{time.Date(2021, 7, 19, 14, 30, 0, 0, time.UTC), 1},
+		{time.Date(2021, 7, 26, 14, 30, 0, 0, time.UTC), 0},
+	}
+	for _, c := range cases {
+		if events := calendar.GetEventsFor(c.date); len(events) != c.count {
+			t.Errorf("Expected %d events on %s, got %d", c.count, c.date, len(events))
+		}
+	}
+}
+
+func TestRepeatRuleApply(t *testing.T) {
+	content := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:weekly",
+		"SUMMARY:Weekly",
+		"DTSTART:20210705T090000Z",
+		"DTEND:20210705T100000Z",
+		"RRULE:FREQ=WEEKLY",
+		"EXDATE:20210712T090000Z",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	cases := []struct {
+		options ParseOptions
+		dates   []time.Time
+	}{
+		{ParseOptions{RepeatRuleApply: true, MaxRepeats: 3}, []time.Time{
+			time.Date(2021, 7, 5, 9, 0, 0, 0, time.UTC),
+			time.Date(2021, 7, 19, 9, 0, 0, 0, time.UTC),
+			time.Date(2021, 7, 26, 9, 0, 0, 0, time.UTC),
+		}},
+		{ParseOptions{RepeatRuleApply: true, RepeatUntil: time.Date(2021, 7, 20, 0, 0, 0, 0, time.UTC)}, []time.Time{
+			time.Date(2021, 7, 5, 9, 0, 0, 0, time.UTC),
+			time.Date(2021, 7, 19, 9, 0, 0, 0, time.UTC),
+		}},
+		{DefaultParseOptions(), []time.Time{}},
+	}
+	for _, c := range cases {
+		p := createParser(nil)
+		p.setOptions(c.options)
+		calendar := newCalendar("")
+		p.parseContent(calendar, content)
+
+		repeated := 0
+		for _, date := range c.dates {
+			indices := calendar.GetEventIndicesByDate(date)
+			if len(indices) != 1 {
+				t.Errorf("Expected a repeated event on %s, found %d", date, len(indices))
+				continue
+			}
+			repeated++
+			event, _ := calendar.GetEventByIndex(indices[0])
+			if !event.Start.Equal(date) || event.Rrule != "" || event.Summary != "Weekly" {
+				t.Errorf("Expected a single event at %s, found %s", date, event)
+			}
+		}
+		if len(calendar.Events) != 1+repeated {
+			t.Errorf("Expected %d repeated events, found %d", repeated, len(calendar.Events)-1)
+		}
+		if events := calendar.GetEventsFor(time.Date(2021, 7, 19, 9, 30, 0, 0, time.UTC)); len(events) != 1 {
+			t.Errorf("Expected one event on 2021-07-19, got %d", len(events))
+		}
+		if occurrences := calendar.Occurrences(time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC)); len(occurrences) != 3 {
+			t.Errorf("Expected 3 occurrences in July, got %d", len(occurrences))
+		}
+	}
+
+	calendar := NewFileCalendar("", "testCalendars/2eventsCal.ics")
+	calendar.SetParseOptions(ParseOptions{RepeatRuleApply: true})
+	if options := calendar.GetParseOptions(); !options.RepeatRuleApply || options.MaxRepeats != DefaultMaxRepeats {
+		t.Errorf("Expected the default maximum number of repeats, got %d", options.MaxRepeats)
+	}
+}
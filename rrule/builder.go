@@ -0,0 +1,160 @@
+package rrule
+
+import (
+	"errors"
+	"time"
+)
+
+// Builder builds the options of a rule step by step, e.g. Every(2).Weeks().On(MO, WE).Until(t),
+// Build validates them and returns the rule
+type Builder struct {
+	option ROption
+}
+
+// Every starts a rule that repeats every interval periods, the period is YEARLY unless it is
+// set with Years, Months, Weeks, Days, Hours, Minutes or Seconds
+func Every(interval int) *Builder {
+	return &Builder{option: ROption{Freq: YEARLY, Interval: interval}}
+}
+
+// Years repeats the rule every interval years
+func (b *Builder) Years() *Builder {
+	b.option.Freq = YEARLY
+	return b
+}
+
+// Months repeats the rule every interval months
+func (b *Builder) Months() *Builder {
+	b.option.Freq = MONTHLY
+	return b
+}
+
+// Weeks repeats the rule every interval weeks
+func (b *Builder) Weeks() *Builder {
+	b.option.Freq = WEEKLY
+	return b
+}
+
+// Days repeats the rule every interval days
+func (b *Builder) Days() *Builder {
+	b.option.Freq = DAILY
+	return b
+}
+
+// Hours repeats the rule every interval hours
+func (b *Builder) Hours() *Builder {
+	b.option.Freq = HOURLY
+	return b
+}
+
+// Minutes repeats the rule every interval minutes
+func (b *Builder) Minutes() *Builder {
+	b.option.Freq = MINUTELY
+	return b
+}
+
+// Seconds repeats the rule every interval seconds
+func (b *Builder) Seconds() *Builder {
+	b.option.Freq = SECONDLY
+	return b
+}
+
+// On limits the rule to the weekdays (BYDAY), use Nth for the n-th weekday of the period,
+// e.g. On(FR.Nth(-1)) for the last Friday
+func (b *Builder) On(weekdays ...RWeekday) *Builder {
+	b.option.Byweekday = append(b.option.Byweekday, weekdays...)
+	return b
+}
+
+// OnWeekdays is the same as On but takes the weekdays of the time package
+func (b *Builder) OnWeekdays(weekdays ...time.Weekday) *Builder {
+	for _, weekday := range weekdays {
+		b.option.Byweekday = append(b.option.Byweekday, RWeekday{weekday: toPyWeekday(weekday)})
+	}
+	return b
+}
+
+// OnNth limits the rule to the n-th weekday of the period, negative numbers count from the end
+func (b *Builder) OnNth(n int, weekday time.Weekday) *Builder {
+	b.option.Byweekday = append(b.option.Byweekday, RWeekday{weekday: toPyWeekday(weekday), n: n})
+	return b
+}
+
+// OnDays limits the rule to the days of the month (BYMONTHDAY), negative days count from the end
+func (b *Builder) OnDays(days ...int) *Builder {
+	b.option.Bymonthday = append(b.option.Bymonthday, days...)
+	return b
+}
+
+// In limits the rule to the months (BYMONTH)
+func (b *Builder) In(months ...time.Month) *Builder {
+	for _, month := range months {
+		b.option.Bymonth = append(b.option.Bymonth, int(month))
+	}
+	return b
+}
+
+// AtHours sets the hours of the occurrences (BYHOUR)
+func (b *Builder) AtHours(hours ...int) *Builder {
+	b.option.Byhour = append(b.option.Byhour, hours...)
+	return b
+}
+
+// AtMinutes sets the minutes of the occurrences (BYMINUTE)
+func (b *Builder) AtMinutes(minutes ...int) *Builder {
+	b.option.Byminute = append(b.option.Byminute, minutes...)
+	return b
+}
+
+// AtSeconds sets the seconds of the occurrences (BYSECOND)
+func (b *Builder) AtSeconds(seconds ...int) *Builder {
+	b.option.Bysecond = append(b.option.Bysecond, seconds...)
+	return b
+}
+
+// Positions limits the occurrences in every period to the positions (BYSETPOS), negative
+// positions count from the end
+func (b *Builder) Positions(positions ...int) *Builder {
+	b.option.Bysetpos = append(b.option.Bysetpos, positions...)
+	return b
+}
+
+// WeekStart sets the first day of the week (WKST)
+func (b *Builder) WeekStart(weekday time.Weekday) *Builder {
+	b.option.Wkst = RWeekday{weekday: toPyWeekday(weekday)}
+	return b
+}
+
+// From sets the start of the rule (DTSTART)
+func (b *Builder) From(dtstart time.Time) *Builder {
+	b.option.Dtstart = dtstart
+	return b
+}
+
+// Until sets the end of the rule (UNTIL)
+func (b *Builder) Until(until time.Time) *Builder {
+	b.option.Until = until
+	return b
+}
+
+// Count sets the number of occurrences of the rule (COUNT)
+func (b *Builder) Count(count int) *Builder {
+	b.option.Count = count
+	return b
+}
+
+// Options returns the options that are built so far
+func (b *Builder) Options() ROption {
+	return b.option
+}
+
+// Build validates the options and returns the rule
+func (b *Builder) Build() (*RRule, error) {
+	if b.option.Interval < 1 {
+		return nil, errors.New("interval must be greater than 0")
+	}
+	if b.option.Count < 0 {
+		return nil, errors.New("count must not be negative")
+	}
+	return NewRRule(b.option)
+}
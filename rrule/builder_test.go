@@ -0,0 +1,40 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuilder(t *testing.T) {
+	dtstart := time.Date(2021, 7, 5, 9, 0, 0, 0, time.UTC)
+	until := time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC)
+	r, err := Every(2).Weeks().On(MO, WE).From(dtstart).Until(until).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := StrToRRule("FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;UNTIL=20210801T000000Z;DTSTART=20210705T090000Z")
+	if value := r.All(); !timesEqual(value, want.All()) {
+		t.Errorf("get %v, want %v", value, want.All())
+	}
+	if value := r.RRuleString(); value != "FREQ=WEEKLY;INTERVAL=2;UNTIL=20210801T000000Z;BYDAY=MO,WE" {
+		t.Errorf("get %v, want %v", value, "FREQ=WEEKLY;INTERVAL=2;UNTIL=20210801T000000Z;BYDAY=MO,WE")
+	}
+
+	option := Every(1).Months().OnNth(-1, time.Friday).OnWeekdays(time.Sunday).In(time.March).
+		AtHours(9).AtMinutes(30).Positions(1).WeekStart(time.Sunday).Count(3).Options()
+	if value := option.RRuleString(); value != "FREQ=MONTHLY;INTERVAL=1;WKST=SU;COUNT=3;BYSETPOS=1;BYMONTH=3;BYDAY=-1FR,SU;BYHOUR=9;BYMINUTE=30" {
+		t.Errorf("get %v, want %v", value, "FREQ=MONTHLY;INTERVAL=1;WKST=SU;COUNT=3;BYSETPOS=1;BYMONTH=3;BYDAY=-1FR,SU;BYHOUR=9;BYMINUTE=30")
+	}
+
+	for _, b := range []*Builder{
+		Every(0).Days(),
+		Every(1).Days().Count(-1),
+		Every(1).Months().OnDays(32),
+		Every(1).Days().AtHours(24),
+	} {
+		if _, err := b.Build(); err == nil {
+			option := b.Options()
+			t.Errorf("get %v, want error", option.String())
+		}
+	}
+}
@@ -35,7 +35,7 @@ func exampleRRule() {
 		Interval:   4,
 		Count:      3,
 		Bymonth:    []int{11},
-		Byweekday:  []rrule.Weekday{rrule.TU},
+		Byweekday:  []rrule.RWeekday{rrule.TU},
 		Bymonthday: []int{2, 3, 4, 5, 6, 7, 8},
 		Dtstart:    time.Date(1996, 11, 5, 9, 0, 0, 0, time.Local)})
 	fmt.Println(r.All())
@@ -54,7 +54,7 @@ func exampleRRuleSet() {
 	set.RRule(r)
 	r, _ = rrule.NewRRule(rrule.ROption{
 		Freq:      rrule.YEARLY,
-		Byweekday: []rrule.Weekday{rrule.SA, rrule.SU},
+		Byweekday: []rrule.RWeekday{rrule.SA, rrule.SU},
 		Dtstart:   time.Date(1997, 9, 2, 9, 0, 0, 0, time.Local)})
 	set.ExRule(r)
 	fmt.Println(set.All())
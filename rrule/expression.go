@@ -0,0 +1,239 @@
+package rrule
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// expressionTypes maps the names of the temporal expressions in their JSON encoding to
+// their types, the expressions that hold other expressions or times are encoded separately
+var expressionTypes = map[string]reflect.Type{
+	"alwaysOrNever": reflect.TypeOf(Never),
+	"dayEvent":      reflect.TypeOf(DayEventExpression{}),
+	"timeRange":     reflect.TypeOf(TimeRangeExpression{}),
+	"day":           reflect.TypeOf(DayExpression(0)),
+	"daily":         reflect.TypeOf(DailyExpression{}),
+	"dayRange":      reflect.TypeOf(DayRangeExpression{}),
+	"weekInMonth":   reflect.TypeOf(WeekInMonthExpression(0)),
+	"weekNumbers":   reflect.TypeOf(WeekNumbersExpression{}),
+	"weekly":        reflect.TypeOf(WeeklyExpression{}),
+	"hourly":        reflect.TypeOf(HourlyExpression{}),
+	"minutely":      reflect.TypeOf(MinutelyExpression{}),
+	"secondly":      reflect.TypeOf(SecondlyExpression{}),
+	"hour":          reflect.TypeOf(HourExpression(0)),
+	"minute":        reflect.TypeOf(MinuteExpression(0)),
+	"second":        reflect.TypeOf(SecondExpression(0)),
+	"weekday":       reflect.TypeOf(Sunday),
+	"nthWeekday":    reflect.TypeOf(NthWeekdayExpression{}),
+	"weekdayRange":  reflect.TypeOf(WeekdayRangeExpression{}),
+	"dateRange":     reflect.TypeOf(DateRangeExpression{}),
+	"month":         reflect.TypeOf(January),
+	"monthly":       reflect.TypeOf(MonthlyExpression{}),
+	"monthRange":    reflect.TypeOf(MonthRangeExpression{}),
+	"year":          reflect.TypeOf(YearExpression(0)),
+	"yearDay":       reflect.TypeOf(YearDayExpression(0)),
+	"easter":        reflect.TypeOf(EasterExpression(0)),
+	"yearly":        reflect.TypeOf(YearlyExpression{}),
+	"yearRange":     reflect.TypeOf(YearRangeExpression{}),
+}
+
+// expressionJSON is the JSON encoding of a temporal expression, the type names the
+// expression and the value holds its fields
+type expressionJSON struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// spanJSON is the value of a SpanExpression
+type spanJSON struct {
+	Days     expressionJSON
+	Starts   []time.Duration
+	Duration time.Duration
+	Period   time.Duration
+}
+
+// setPosJSON is the value of a SetPosExpression
+type setPosJSON struct {
+	Freq       Frequency
+	Wkst       time.Weekday
+	Positions  []int
+	Days       expressionJSON
+	Times      []time.Duration
+	Resolution time.Duration
+}
+
+// exceptJSON is the value of an ExceptExpression
+type exceptJSON struct {
+	Expression expressionJSON
+	Dates      []time.Time
+}
+
+// MarshalExpression returns the JSON encoding of a temporal expression that is composed of
+// the expressions of this package, e.g. {"type":"and","value":[{"type":"weekday","value":1},...]},
+// UnmarshalExpression returns the expression again
+func MarshalExpression(e TemporalExpression) ([]byte, error) {
+	ej, err := encodeExpression(e)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(ej)
+}
+
+// UnmarshalExpression returns the temporal expression of its JSON encoding
+func UnmarshalExpression(data []byte) (TemporalExpression, error) {
+	var ej expressionJSON
+	if err := json.Unmarshal(data, &ej); err != nil {
+		return nil, err
+	}
+	e, err := decodeExpression(ej)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func encodeExpression(e TemporalExpression) (expressionJSON, error) {
+	var name string
+	var value interface{}
+	var err error
+	switch e := e.(type) {
+	case OrExpression:
+		name = "or"
+		value, err = encodeExpressions(e.Expressions)
+	case *OrExpression:
+		return encodeExpression(*e)
+	case AndExpression:
+		name = "and"
+		value, err = encodeExpressions(e.Expressions)
+	case *AndExpression:
+		return encodeExpression(*e)
+	case NotExpression:
+		name = "not"
+		value, err = encodeExpression(e.Expression)
+	case ExceptExpression:
+		name = "except"
+		v := exceptJSON{Dates: e.Dates}
+		v.Expression, err = encodeExpression(e.Expression)
+		value = v
+	case SpanExpression:
+		name = "span"
+		v := spanJSON{Starts: e.Starts, Duration: e.Duration, Period: e.Period}
+		v.Days, err = encodeExpression(e.Days)
+		value = v
+	case SetPosExpression:
+		name = "setPos"
+		v := setPosJSON{Freq: e.Freq, Wkst: e.Wkst, Positions: e.Positions, Times: e.Times, Resolution: e.Resolution}
+		v.Days, err = encodeExpression(e.Days)
+		value = v
+	case BeforeDateExpression:
+		name, value = "beforeDate", time.Time(e)
+	case AfterDateExpression:
+		name, value = "afterDate", time.Time(e)
+	case DateExpression:
+		name, value = "date", time.Time(e)
+	default:
+		for n, t := range expressionTypes {
+			if reflect.TypeOf(e) == t {
+				name, value = n, e
+			}
+		}
+		if name == "" {
+			err = fmt.Errorf("unsupported temporal expression %T", e)
+		}
+	}
+	if err != nil {
+		return expressionJSON{}, err
+	}
+	raw, err := json.Marshal(value)
+	return expressionJSON{Type: name, Value: raw}, err
+}
+
+func encodeExpressions(ee []TemporalExpression) ([]expressionJSON, error) {
+	ejs := make([]expressionJSON, len(ee))
+	for i, e := range ee {
+		ej, err := encodeExpression(e)
+		if err != nil {
+			return nil, err
+		}
+		ejs[i] = ej
+	}
+	return ejs, nil
+}
+
+func decodeExpression(ej expressionJSON) (TemporalExpression, error) {
+	switch ej.Type {
+	case "or", "and":
+		var ejs []expressionJSON
+		if err := json.Unmarshal(ej.Value, &ejs); err != nil {
+			return nil, err
+		}
+		ee, err := decodeExpressions(ejs)
+		if ej.Type == "or" {
+			return Or(ee...), err
+		}
+		return And(ee...), err
+	case "not":
+		var child expressionJSON
+		if err := json.Unmarshal(ej.Value, &child); err != nil {
+			return nil, err
+		}
+		e, err := decodeExpression(child)
+		return Not(e), err
+	case "except":
+		var v exceptJSON
+		if err := json.Unmarshal(ej.Value, &v); err != nil {
+			return nil, err
+		}
+		e, err := decodeExpression(v.Expression)
+		return Except(e, v.Dates...), err
+	case "span":
+		var v spanJSON
+		if err := json.Unmarshal(ej.Value, &v); err != nil {
+			return nil, err
+		}
+		days, err := decodeExpression(v.Days)
+		return SpanExpression{Days: days, Starts: v.Starts, Duration: v.Duration, Period: v.Period}, err
+	case "setPos":
+		var v setPosJSON
+		if err := json.Unmarshal(ej.Value, &v); err != nil {
+			return nil, err
+		}
+		days, err := decodeExpression(v.Days)
+		return SetPosExpression{Freq: v.Freq, Wkst: v.Wkst, Positions: v.Positions, Days: days, Times: v.Times, Resolution: v.Resolution}, err
+	case "beforeDate", "afterDate", "date":
+		var t time.Time
+		if err := json.Unmarshal(ej.Value, &t); err != nil {
+			return nil, err
+		}
+		switch ej.Type {
+		case "beforeDate":
+			return BeforeDateExpression(t), nil
+		case "afterDate":
+			return AfterDateExpression(t), nil
+		}
+		return DateExpression(t), nil
+	}
+	typ, ok := expressionTypes[ej.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown temporal expression %q", ej.Type)
+	}
+	v := reflect.New(typ)
+	if err := json.Unmarshal(ej.Value, v.Interface()); err != nil {
+		return nil, err
+	}
+	return v.Elem().Interface().(TemporalExpression), nil
+}
+
+func decodeExpressions(ejs []expressionJSON) ([]TemporalExpression, error) {
+	ee := make([]TemporalExpression, len(ejs))
+	for i, ej := range ejs {
+		e, err := decodeExpression(ej)
+		if err != nil {
+			return nil, err
+		}
+		ee[i] = e
+	}
+	return ee, nil
+}
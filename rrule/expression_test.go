@@ -0,0 +1,79 @@
+package rrule
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExceptAndTimeRange(t *testing.T) {
+	holiday := time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC)
+	office := And(Weekdays(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday),
+		TimeRange(time.Date(0, 1, 1, 9, 0, 0, 0, time.UTC), time.Date(0, 1, 1, 17, 30, 0, 0, time.UTC)))
+	e := Except(office, holiday)
+	cases := []struct {
+		t    time.Time
+		want bool
+	}{
+		{time.Date(2026, 12, 24, 9, 0, 0, 0, time.UTC), true},
+		{time.Date(2026, 12, 24, 17, 29, 59, 0, time.UTC), true},
+		{time.Date(2026, 12, 24, 17, 30, 0, 0, time.UTC), false},
+		{time.Date(2026, 12, 24, 8, 59, 0, 0, time.UTC), false},
+		{time.Date(2026, 12, 25, 10, 0, 0, 0, time.UTC), false},
+		{time.Date(2026, 12, 26, 10, 0, 0, 0, time.UTC), false},
+	}
+	for _, c := range cases {
+		if got := e.Includes(c.t); got != c.want {
+			t.Errorf("%v: get %v, want %v", c.t, got, c.want)
+		}
+	}
+
+	night := TimeRangeExpression{Start: 22 * time.Hour, End: 6 * time.Hour}
+	for hour, want := range map[int]bool{21: false, 22: true, 0: true, 5: true, 6: false} {
+		if got := night.Includes(time.Date(2026, 1, 1, hour, 0, 0, 0, time.UTC)); got != want {
+			t.Errorf("night at %d: get %v, want %v", hour, got, want)
+		}
+	}
+}
+
+func TestExpressionRoundTrip(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	expressions := []TemporalExpression{
+		Except(Or(NthWeekday(time.Friday, -1), And(Days(1, 15), Months(time.March, time.June))),
+			time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)),
+		And(Not(Weekdays(time.Saturday, time.Sunday)), TimeRangeExpression{Start: 22 * time.Hour, End: 2 * time.Hour}),
+		Or(DateRange(time.Date(0, 12, 20, 0, 0, 0, 0, time.UTC), time.Date(0, 1, 6, 0, 0, 0, 0, time.UTC)),
+			Easter(0, 1), YearDays(-1), WeeksInMonth(2), DayRange(10, -10)),
+		And(BeforeDate(time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)), AfterDate(start, true), Daily(2026, 1, 3, 4, 0)),
+		Span(Weekdays(time.Tuesday), 3*time.Hour, time.Date(0, 1, 1, 23, 0, 0, 0, time.UTC)),
+		SetPosExpression{Freq: MONTHLY, Wkst: time.Monday, Positions: []int{-1},
+			Days: Weekdays(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday), Times: []time.Duration{0}, Resolution: 24 * time.Hour},
+		And(Always, Yearly(2026, 1, 0), MonthRange(time.April, time.October), WeekdayRange(time.Monday, time.Friday),
+			WeekNumbers(time.Monday, 10, 20), Hours(8, 20), Minutes(0), Seconds(0), DayEvent(start.Add(8*time.Hour), start.Add(9*time.Hour))),
+	}
+	for _, e := range expressions {
+		data, err := MarshalExpression(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+		loaded, err := UnmarshalExpression(data)
+		if err != nil {
+			t.Fatalf("%s: %v", data, err)
+		}
+		for tt := start; tt.Year() == 2026; tt = tt.Add(time.Hour) {
+			if e.Includes(tt) != loaded.Includes(tt) {
+				t.Errorf("%s at %v: get %v, want %v", data, tt, loaded.Includes(tt), e.Includes(tt))
+				break
+			}
+		}
+	}
+}
+
+func TestExpressionInvalid(t *testing.T) {
+	if _, err := MarshalExpression(Or(&RRule{})); err == nil {
+		t.Error("expected an error for a rule")
+	}
+	if _, err := UnmarshalExpression([]byte(`{"type":"and","value":[{"type":"sometimes"}]}`)); err == nil || !strings.Contains(err.Error(), "sometimes") {
+		t.Errorf("get %v, want an error for the unknown expression", err)
+	}
+}
@@ -0,0 +1,556 @@
+package rrule
+
+import (
+	"sort"
+	"time"
+)
+
+type iterInfo struct {
+	rrule       *RRule
+	lastyear    int
+	lastmonth   time.Month
+	yearlen     int
+	nextyearlen int
+	firstyday   time.Time
+	yearweekday int
+	mmask       []int
+	mrange      []int
+	mdaymask    []int
+	nmdaymask   []int
+	wdaymask    []int
+	wnomask     []int
+	nwdaymask   []int
+	eastermask  []int
+}
+
+func (info *iterInfo) rebuild(year int, month time.Month) {
+	// Every mask is 7 days longer to handle cross-year weekly periods.
+	if year != info.lastyear {
+		info.yearlen = 365 + isLeap(year)
+		info.nextyearlen = 365 + isLeap(year+1)
+		info.firstyday = time.Date(year, time.January, 1, 0, 0, 0, 0, info.rrule.dtstart.Location())
+		info.yearweekday = toPyWeekday(info.firstyday.Weekday())
+		info.wdaymask = WDAYMASK[info.yearweekday:]
+		if info.yearlen == 365 {
+			info.mmask = M365MASK
+			info.mdaymask = MDAY365MASK
+			info.nmdaymask = NMDAY365MASK
+			info.mrange = M365RANGE
+		} else {
+			info.mmask = M366MASK
+			info.mdaymask = MDAY366MASK
+			info.nmdaymask = NMDAY366MASK
+			info.mrange = M366RANGE
+		}
+		if len(info.rrule.byweekno) == 0 {
+			info.wnomask = nil
+		} else {
+			info.wnomask = weekNumberMask(year, info.rrule.byweekno, info.rrule.wkst)
+		}
+	}
+	if len(info.rrule.bynweekday) != 0 && (month != info.lastmonth || year != info.lastyear) {
+		var ranges [][]int
+		if info.rrule.freq == YEARLY {
+			if len(info.rrule.bymonth) != 0 {
+				for _, month := range info.rrule.bymonth {
+					ranges = append(ranges, info.mrange[month-1:month+1])
+				}
+			} else {
+				ranges = [][]int{{0, info.yearlen}}
+			}
+		} else if info.rrule.freq == MONTHLY {
+			ranges = [][]int{info.mrange[month-1 : month+1]}
+		}
+		if len(ranges) != 0 {
+			// Weekly frequency won't get here, so we may not
+			// care about cross-year weekly periods.
+			info.nwdaymask = make([]int, info.yearlen)
+			for _, x := range ranges {
+				first, last := x[0], x[1]-1
+				for _, y := range info.rrule.bynweekday {
+					wday, n := y.weekday, y.n
+					var i int
+					if n < 0 {
+						i = last + (n+1)*7
+						i -= pymod(info.wdaymask[i]-wday, 7)
+					} else {
+						i = first + (n-1)*7
+						i += pymod(7-info.wdaymask[i]+wday, 7)
+					}
+					if first <= i && i <= last {
+						info.nwdaymask[i] = 1
+					}
+				}
+			}
+		}
+	}
+	if len(info.rrule.byeaster) != 0 {
+		info.eastermask = make([]int, info.yearlen+7)
+		eyday := easter(year).YearDay() - 1
+		for _, offset := range info.rrule.byeaster {
+			if i := eyday + offset; i >= 0 && i < len(info.eastermask) {
+				info.eastermask[i] = 1
+			}
+		}
+	}
+	info.lastyear = year
+	info.lastmonth = month
+}
+
+// weekNumberMask returns a mask with the days of the year that are in one of the
+// weeks (BYWEEKNO), weeks start at wkst (0 for MO), the mask is 7 days longer to
+// handle cross-year weekly periods
+func weekNumberMask(year int, weeks []int, wkst int) []int {
+	yearlen := 365 + isLeap(year)
+	yearweekday := toPyWeekday(time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC).Weekday())
+	wdaymask := WDAYMASK[yearweekday:]
+	wnomask := make([]int, yearlen+7)
+	firstwkst := pymod(7-yearweekday+wkst, 7)
+	no1wkst := firstwkst
+	var wyearlen int
+	if no1wkst >= 4 {
+		no1wkst = 0
+		// Number of days in the year, plus the days we got from last year.
+		wyearlen = yearlen + pymod(yearweekday-wkst, 7)
+	} else {
+		// Number of days in the year, minus the days we left in last year.
+		wyearlen = yearlen - no1wkst
+	}
+	div, mod := divmod(wyearlen, 7)
+	numweeks := div + mod/4
+	for _, n := range weeks {
+		if n < 0 {
+			n += numweeks + 1
+		}
+		if !(0 < n && n <= numweeks) {
+			continue
+		}
+		var i int
+		if n > 1 {
+			i = no1wkst + (n-1)*7
+			if no1wkst != firstwkst {
+				i -= 7 - firstwkst
+			}
+		} else {
+			i = no1wkst
+		}
+		for j := 0; j < 7; j++ {
+			wnomask[i] = 1
+			i++
+			if wdaymask[i] == wkst {
+				break
+			}
+		}
+	}
+	if contains(weeks, 1) {
+		// Check week number 1 of next year as well
+		i := no1wkst + numweeks*7
+		if no1wkst != firstwkst {
+			i -= 7 - firstwkst
+		}
+		if i < yearlen {
+			// If week starts in next year, we don't care about it.
+			for j := 0; j < 7; j++ {
+				wnomask[i] = 1
+				i++
+				if wdaymask[i] == wkst {
+					break
+				}
+			}
+		}
+	}
+	if no1wkst != 0 {
+		// Check last week number of last year as well. If no1wkst is 0, either
+		// the year started on week start, or week number 1 got days from last
+		// year, so there are no days from last year's last week number in this year.
+		var lnumweeks int
+		if !contains(weeks, -1) {
+			lyearweekday := toPyWeekday(time.Date(year-1, 1, 1, 0, 0, 0, 0, time.UTC).Weekday())
+			lno1wkst := pymod(7-lyearweekday+wkst, 7)
+			lyearlen := 365 + isLeap(year-1)
+			if lno1wkst >= 4 {
+				lnumweeks = 52 + pymod(lyearlen+pymod(lyearweekday-wkst, 7), 7)/4
+			} else {
+				lnumweeks = 52 + pymod(yearlen-no1wkst, 7)/4
+			}
+		} else {
+			lnumweeks = -1
+		}
+		if contains(weeks, lnumweeks) {
+			for i := 0; i < no1wkst; i++ {
+				wnomask[i] = 1
+			}
+		}
+	}
+	return wnomask
+}
+
+// getdayset returns the days of the year in the period that contains the given day
+func (info *iterInfo) getdayset(freq Frequency, year int, month time.Month, day int) ([]*int, int, int) {
+	switch freq {
+	case YEARLY:
+		set := make([]*int, info.yearlen)
+		for i := 0; i < info.yearlen; i++ {
+			temp := i
+			set[i] = &temp
+		}
+		return set, 0, info.yearlen
+	case MONTHLY:
+		set := make([]*int, info.yearlen)
+		start, end := info.mrange[month-1], info.mrange[month]
+		for i := start; i < end; i++ {
+			temp := i
+			set[i] = &temp
+		}
+		return set, start, end
+	case WEEKLY:
+		// We need to handle cross-year weeks here.
+		set := make([]*int, info.yearlen+7)
+		i := time.Date(year, month, day, 0, 0, 0, 0, time.UTC).YearDay() - 1
+		start := i
+		for j := 0; j < 7; j++ {
+			temp := i
+			set[i] = &temp
+			i++
+			// This will cross the year boundary, if necessary.
+			if info.wdaymask[i] == info.rrule.wkst {
+				break
+			}
+		}
+		return set, start, i
+	}
+	// DAILY, HOURLY, MINUTELY, SECONDLY:
+	set := make([]*int, info.yearlen)
+	i := time.Date(year, month, day, 0, 0, 0, 0, time.UTC).YearDay() - 1
+	set[i] = &i
+	return set, i, i + 1
+}
+
+// gettimeset returns the times of the day in the period for the sub-daily frequencies
+func (info *iterInfo) gettimeset(freq Frequency, hour, minute, second int) (result []time.Time) {
+	loc := info.rrule.dtstart.Location()
+	switch freq {
+	case HOURLY:
+		for _, minute := range info.rrule.byminute {
+			for _, second := range info.rrule.bysecond {
+				result = append(result, time.Date(1, 1, 1, hour, minute, second, 0, loc))
+			}
+		}
+		sort.Sort(timeSlice(result))
+	case MINUTELY:
+		for _, second := range info.rrule.bysecond {
+			result = append(result, time.Date(1, 1, 1, hour, minute, second, 0, loc))
+		}
+		sort.Sort(timeSlice(result))
+	case SECONDLY:
+		result = []time.Time{time.Date(1, 1, 1, hour, minute, second, 0, loc)}
+	default:
+		result = info.rrule.timeset
+	}
+	return
+}
+
+type rIterator struct {
+	year     int
+	month    time.Month
+	day      int
+	hour     int
+	minute   int
+	second   int
+	weekday  int
+	ii       iterInfo
+	timeset  []time.Time
+	total    int
+	count    int
+	remain   []time.Time
+	finished bool
+}
+
+// filtered returns true when the day of the year is removed by one of the BY* rules
+func (iterator *rIterator) filtered(i int) bool {
+	r := iterator.ii.rrule
+	ii := &iterator.ii
+	return len(r.bymonth) != 0 && !contains(r.bymonth, ii.mmask[i]) ||
+		len(r.byweekno) != 0 && ii.wnomask[i] == 0 ||
+		len(r.byweekday) != 0 && !contains(r.byweekday, ii.wdaymask[i]) ||
+		len(ii.nwdaymask) != 0 && (i >= len(ii.nwdaymask) || ii.nwdaymask[i] == 0) ||
+		len(r.byeaster) != 0 && ii.eastermask[i] == 0 ||
+		(len(r.bymonthday) != 0 || len(r.bynmonthday) != 0) &&
+			!contains(r.bymonthday, ii.mdaymask[i]) &&
+			!contains(r.bynmonthday, ii.nmdaymask[i]) ||
+		len(r.byyearday) != 0 &&
+			(i < ii.yearlen &&
+				!contains(r.byyearday, i+1) &&
+				!contains(r.byyearday, -ii.yearlen+i) ||
+				i >= ii.yearlen &&
+					!contains(r.byyearday, i+1-ii.yearlen) &&
+					!contains(r.byyearday, -ii.nextyearlen+i-ii.yearlen))
+}
+
+// emit adds an occurrence, it returns false when the iteration is finished by UNTIL or COUNT
+func (iterator *rIterator) emit(res time.Time) bool {
+	r := iterator.ii.rrule
+	if !r.until.IsZero() && res.After(r.until) {
+		r.len = iterator.total
+		iterator.finished = true
+		return false
+	}
+	if !res.Before(r.dtstart) {
+		iterator.total++
+		iterator.remain = append(iterator.remain, res)
+		if iterator.count != 0 {
+			iterator.count--
+			if iterator.count == 0 {
+				r.len = iterator.total
+				iterator.finished = true
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (iterator *rIterator) generate() {
+	r := iterator.ii.rrule
+	for len(iterator.remain) == 0 {
+		// Get dayset with the right frequency
+		dayset, start, end := iterator.ii.getdayset(r.freq, iterator.year, iterator.month, iterator.day)
+
+		// Do the "hard" work ;-)
+		filtered := false
+		for _, i := range dayset[start:end] {
+			if i != nil && iterator.filtered(*i) {
+				dayset[*i] = nil
+				filtered = true
+			}
+		}
+
+		// Output results
+		if len(r.bysetpos) != 0 && len(iterator.timeset) != 0 {
+			days := []int{}
+			for _, x := range dayset[start:end] {
+				if x != nil {
+					days = append(days, *x)
+				}
+			}
+			poslist := []time.Time{}
+			for _, pos := range r.bysetpos {
+				var daypos, timepos int
+				if pos < 0 {
+					daypos, timepos = divmod(pos, len(iterator.timeset))
+				} else {
+					daypos, timepos = divmod(pos-1, len(iterator.timeset))
+				}
+				i, err := pySubscript(days, daypos)
+				if err != nil {
+					continue
+				}
+				timeTemp := iterator.timeset[timepos]
+				date := iterator.ii.firstyday.AddDate(0, 0, i)
+				res := time.Date(date.Year(), date.Month(), date.Day(),
+					timeTemp.Hour(), timeTemp.Minute(), timeTemp.Second(),
+					timeTemp.Nanosecond(), timeTemp.Location())
+				if !timeContains(poslist, res) {
+					poslist = append(poslist, res)
+				}
+			}
+			sort.Sort(timeSlice(poslist))
+			for _, res := range poslist {
+				if !iterator.emit(res) {
+					return
+				}
+			}
+		} else {
+			for _, i := range dayset[start:end] {
+				if i == nil {
+					continue
+				}
+				date := iterator.ii.firstyday.AddDate(0, 0, *i)
+				for _, timeTemp := range iterator.timeset {
+					res := time.Date(date.Year(), date.Month(), date.Day(),
+						timeTemp.Hour(), timeTemp.Minute(), timeTemp.Second(),
+						timeTemp.Nanosecond(), timeTemp.Location())
+					if !iterator.emit(res) {
+						return
+					}
+				}
+			}
+		}
+
+		// Handle frequency and interval
+		if !iterator.advance(filtered) {
+			r.len = iterator.total
+			iterator.finished = true
+			return
+		}
+	}
+}
+
+// advance moves the iterator to the next period, it returns false when MAXYEAR is passed
+func (iterator *rIterator) advance(filtered bool) bool {
+	r := iterator.ii.rrule
+	fixday := false
+	switch r.freq {
+	case YEARLY:
+		iterator.year += r.interval
+		if iterator.year > MAXYEAR {
+			return false
+		}
+		iterator.ii.rebuild(iterator.year, 0)
+	case MONTHLY:
+		iterator.month += time.Month(r.interval)
+		if iterator.month > 12 {
+			div, mod := divmod(int(iterator.month), 12)
+			iterator.month = time.Month(mod)
+			iterator.year += div
+			if iterator.month == 0 {
+				iterator.month = 12
+				iterator.year--
+			}
+			if iterator.year > MAXYEAR {
+				return false
+			}
+		}
+		iterator.ii.rebuild(iterator.year, iterator.month)
+	case WEEKLY:
+		if r.wkst > iterator.weekday {
+			iterator.day += -(iterator.weekday + 1 + (6 - r.wkst)) + r.interval*7
+		} else {
+			iterator.day += -(iterator.weekday - r.wkst) + r.interval*7
+		}
+		iterator.weekday = r.wkst
+		fixday = true
+	case DAILY:
+		iterator.day += r.interval
+		fixday = true
+	case HOURLY:
+		if filtered {
+			// Jump to one iteration before next day
+			iterator.hour += ((23 - iterator.hour) / r.interval) * r.interval
+		}
+		for {
+			iterator.hour += r.interval
+			div, mod := divmod(iterator.hour, 24)
+			if div != 0 {
+				iterator.hour = mod
+				iterator.day += div
+				fixday = true
+			}
+			if len(r.byhour) == 0 || contains(r.byhour, iterator.hour) {
+				break
+			}
+		}
+		iterator.timeset = iterator.ii.gettimeset(r.freq, iterator.hour, iterator.minute, iterator.second)
+	case MINUTELY:
+		if filtered {
+			// Jump to one iteration before next day
+			iterator.minute += ((1439 - (iterator.hour*60 + iterator.minute)) / r.interval) * r.interval
+		}
+		for {
+			iterator.minute += r.interval
+			div, mod := divmod(iterator.minute, 60)
+			if div != 0 {
+				iterator.minute = mod
+				iterator.hour += div
+				div, mod = divmod(iterator.hour, 24)
+				if div != 0 {
+					iterator.hour = mod
+					iterator.day += div
+					fixday = true
+				}
+			}
+			if (len(r.byhour) == 0 || contains(r.byhour, iterator.hour)) &&
+				(len(r.byminute) == 0 || contains(r.byminute, iterator.minute)) {
+				break
+			}
+		}
+		iterator.timeset = iterator.ii.gettimeset(r.freq, iterator.hour, iterator.minute, iterator.second)
+	case SECONDLY:
+		if filtered {
+			// Jump to one iteration before next day
+			iterator.second += ((86399 - (iterator.hour*3600 + iterator.minute*60 + iterator.second)) / r.interval) * r.interval
+		}
+		for {
+			iterator.second += r.interval
+			div, mod := divmod(iterator.second, 60)
+			if div != 0 {
+				iterator.second = mod
+				iterator.minute += div
+				div, mod = divmod(iterator.minute, 60)
+				if div != 0 {
+					iterator.minute = mod
+					iterator.hour += div
+					div, mod = divmod(iterator.hour, 24)
+					if div != 0 {
+						iterator.hour = mod
+						iterator.day += div
+						fixday = true
+					}
+				}
+			}
+			if (len(r.byhour) == 0 || contains(r.byhour, iterator.hour)) &&
+				(len(r.byminute) == 0 || contains(r.byminute, iterator.minute)) &&
+				(len(r.bysecond) == 0 || contains(r.bysecond, iterator.second)) {
+				break
+			}
+		}
+		iterator.timeset = iterator.ii.gettimeset(r.freq, iterator.hour, iterator.minute, iterator.second)
+	}
+
+	if fixday && iterator.day > 28 {
+		daysinmonth := daysIn(iterator.month, iterator.year)
+		if iterator.day > daysinmonth {
+			for iterator.day > daysinmonth {
+				iterator.day -= daysinmonth
+				iterator.month++
+				if iterator.month == 13 {
+					iterator.month = 1
+					iterator.year++
+					if iterator.year > MAXYEAR {
+						return false
+					}
+				}
+				daysinmonth = daysIn(iterator.month, iterator.year)
+			}
+			iterator.ii.rebuild(iterator.year, iterator.month)
+		}
+	}
+	return true
+}
+
+func (iterator *rIterator) next() (value time.Time, ok bool) {
+	if !iterator.finished {
+		iterator.generate()
+	}
+	if len(iterator.remain) == 0 {
+		return
+	}
+	value = iterator.remain[0]
+	iterator.remain = iterator.remain[1:]
+	return value, true
+}
+
+// Iterator returns a generator of the occurrences of the rule
+func (r *RRule) Iterator() Next {
+	iterator := rIterator{}
+	iterator.year, iterator.month, iterator.day = r.dtstart.Date()
+	iterator.hour, iterator.minute, iterator.second = r.dtstart.Clock()
+	iterator.weekday = toPyWeekday(r.dtstart.Weekday())
+
+	iterator.ii = iterInfo{rrule: r}
+	iterator.ii.rebuild(iterator.year, iterator.month)
+
+	if r.freq < HOURLY {
+		iterator.timeset = r.timeset
+	} else {
+		if r.freq >= HOURLY && len(r.byhour) != 0 && !contains(r.byhour, iterator.hour) ||
+			r.freq >= MINUTELY && len(r.byminute) != 0 && !contains(r.byminute, iterator.minute) ||
+			r.freq >= SECONDLY && len(r.bysecond) != 0 && !contains(r.bysecond, iterator.second) {
+			iterator.timeset = nil
+		} else {
+			iterator.timeset = iterator.ii.gettimeset(r.freq, iterator.hour, iterator.minute, iterator.second)
+		}
+	}
+	iterator.count = r.count
+	return iterator.next
+}
@@ -0,0 +1,356 @@
+package rrule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseText parses a rule that starts at dtstart from an English phrase, e.g. "every weekday",
+// "every 2 weeks on monday and wednesday until 2026-12-31", "first monday of the month at 9:30"
+// or "last day of every month, 12 times"
+func ParseText(text string, dtstart time.Time) (*RRule, error) {
+	p := phraseParser{text: text, words: phraseWords(text), builder: Every(1).From(dtstart)}
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+	return p.builder.Build()
+}
+
+var (
+	phraseUnits = map[string]Frequency{
+		"year": YEARLY, "month": MONTHLY, "week": WEEKLY, "day": DAILY,
+		"hour": HOURLY, "minute": MINUTELY, "second": SECONDLY,
+	}
+	phraseFrequencies = map[string]Frequency{
+		"yearly": YEARLY, "annually": YEARLY, "monthly": MONTHLY, "weekly": WEEKLY,
+		"daily": DAILY, "hourly": HOURLY,
+	}
+	phraseOrdinals = map[string]int{
+		"first": 1, "second": 2, "third": 3, "fourth": 4, "fifth": 5, "last": -1,
+	}
+)
+
+// phraseParser parses the words of a phrase into the options of a builder
+type phraseParser struct {
+	text    string
+	words   []string
+	pos     int
+	builder *Builder
+}
+
+// phraseWords splits a phrase into lowercase words, a comma is read as "and"
+func phraseWords(text string) []string {
+	text = strings.ToLower(strings.Replace(text, ",", " and ", -1))
+	return strings.Fields(text)
+}
+
+func (p *phraseParser) peek() string {
+	if p.pos < len(p.words) {
+		return p.words[p.pos]
+	}
+	return ""
+}
+
+func (p *phraseParser) next() string {
+	word := p.peek()
+	p.pos++
+	return word
+}
+
+// accept consumes the next word when it is one of the words
+func (p *phraseParser) accept(words ...string) bool {
+	for _, word := range words {
+		if p.peek() == word {
+			p.pos++
+			return true
+		}
+	}
+	return false
+}
+
+func (p *phraseParser) unexpected() error {
+	if p.pos >= len(p.words) {
+		return fmt.Errorf("unexpected end of %q", p.text)
+	}
+	return fmt.Errorf("unexpected %q in %q", p.words[p.pos], p.text)
+}
+
+func (p *phraseParser) parse() error {
+	var err error
+	if freq, ok := phraseFrequencies[p.peek()]; ok {
+		p.next()
+		p.builder.option.Freq = freq
+	} else if p.accept("every", "each") {
+		err = p.every()
+	} else {
+		err = p.nthOf()
+	}
+	for err == nil && p.pos < len(p.words) {
+		// the modifiers may be separated by "and", e.g. "every day at 9:00, until 2026-12-31"
+		if p.accept("and") {
+			continue
+		}
+		err = p.modifier()
+	}
+	return err
+}
+
+// every parses what follows "every", a period with an optional interval, weekdays or the n-th
+// day of a period
+func (p *phraseParser) every() error {
+	if p.accept("other") {
+		p.builder.option.Interval = 2
+	} else if n, err := strconv.Atoi(p.peek()); err == nil {
+		p.next()
+		p.builder.option.Interval = n
+	}
+	word := p.peek()
+	// "second" is both a unit and an ordinal, it is the ordinal when a weekday or day follows
+	if _, ok := phraseOrdinal(word); ok && p.pos+1 < len(p.words) {
+		following := p.words[p.pos+1]
+		if _, ok := phraseWeekday(following); ok || following == "day" {
+			return p.nthOf()
+		}
+	}
+	if freq, ok := phraseUnits[strings.TrimSuffix(word, "s")]; ok {
+		p.next()
+		p.builder.option.Freq = freq
+		return nil
+	}
+	switch {
+	case p.accept("weekday", "weekdays"):
+		p.builder.Weeks().On(MO, TU, WE, TH, FR)
+	case p.accept("weekend", "weekends"):
+		p.builder.Weeks().On(SA, SU)
+	case isPhraseWeekday(word):
+		weekdays, err := p.weekdays()
+		if err != nil {
+			return err
+		}
+		p.builder.Weeks().On(weekdays...)
+	default:
+		return p.nthOf()
+	}
+	return nil
+}
+
+// nthOf parses "<ordinal> <weekday|day> of <the month|the year|month name>"
+func (p *phraseParser) nthOf() error {
+	n, ok := phraseOrdinal(p.peek())
+	if !ok {
+		return p.unexpected()
+	}
+	p.next()
+	var weekday *RWeekday
+	if wday, ok := phraseWeekday(p.peek()); ok {
+		p.next()
+		weekday = &wday
+	} else if !p.accept("day") {
+		return p.unexpected()
+	}
+	if !p.accept("of", "in") {
+		return p.unexpected()
+	}
+	p.accept("the", "every", "each")
+	if p.accept("month", "months") {
+		p.builder.Months()
+	} else if p.accept("year", "years") {
+		p.builder.Years()
+	} else if month, ok := phraseMonth(p.peek()); ok {
+		p.next()
+		p.builder.Years().In(month)
+	} else {
+		return p.unexpected()
+	}
+	if weekday != nil {
+		p.builder.On(weekday.Nth(n))
+	} else if p.builder.option.Freq == YEARLY && len(p.builder.option.Bymonth) == 0 {
+		p.builder.option.Byyearday = append(p.builder.option.Byyearday, n)
+	} else {
+		p.builder.OnDays(n)
+	}
+	return nil
+}
+
+// modifier parses a part that limits the rule, e.g. "on monday", "on the 1st and 15th",
+// "in january", "at 9:30", "until 2026-12-31" or "10 times"
+func (p *phraseParser) modifier() error {
+	switch {
+	case p.accept("on"):
+		if p.accept("weekdays") {
+			p.builder.On(MO, TU, WE, TH, FR)
+			return nil
+		}
+		if isPhraseWeekday(p.peek()) {
+			weekdays, err := p.weekdays()
+			p.builder.On(weekdays...)
+			return err
+		}
+		p.accept("the")
+		days, err := p.list(func(word string) (int, bool) { return phraseOrdinal(word) })
+		p.accept("day", "days")
+		p.builder.OnDays(days...)
+		return err
+	case p.accept("in"):
+		months, err := p.list(func(word string) (int, bool) {
+			month, ok := phraseMonth(word)
+			return int(month), ok
+		})
+		for _, month := range months {
+			p.builder.In(time.Month(month))
+		}
+		return err
+	case p.accept("at"):
+		return p.times()
+	case p.accept("until"):
+		until, err := time.ParseInLocation("2006-01-02", p.next(), p.builder.option.Dtstart.Location())
+		if err != nil {
+			return fmt.Errorf("until needs a date like 2006-01-02 in %q", p.text)
+		}
+		// the rule ends at the end of the day
+		p.builder.Until(until.AddDate(0, 0, 1).Add(-time.Second))
+		return nil
+	case p.accept("once"):
+		p.builder.Count(1)
+		return nil
+	case p.accept("twice"):
+		p.builder.Count(2)
+		return nil
+	}
+	p.accept("for")
+	count, err := strconv.Atoi(p.peek())
+	if err != nil {
+		return p.unexpected()
+	}
+	p.next()
+	if !p.accept("times", "time") {
+		return p.unexpected()
+	}
+	p.builder.Count(count)
+	return nil
+}
+
+// weekdays parses a list of weekday names
+func (p *phraseParser) weekdays() ([]RWeekday, error) {
+	days, err := p.list(func(word string) (int, bool) {
+		wday, ok := phraseWeekday(word)
+		return wday.weekday, ok
+	})
+	weekdays := make([]RWeekday, len(days))
+	for i, day := range days {
+		weekdays[i] = RWeekday{weekday: day}
+	}
+	return weekdays, err
+}
+
+// times parses a list of times of the day like 9 or 9:30, all times share their minutes
+func (p *phraseParser) times() error {
+	minutes := map[int]bool{}
+	for {
+		clock, err := time.Parse("15:04", p.peek())
+		if err != nil {
+			clock, err = time.Parse("15", p.peek())
+		}
+		if err != nil {
+			return p.unexpected()
+		}
+		p.next()
+		p.builder.AtHours(clock.Hour())
+		if !minutes[clock.Minute()] {
+			minutes[clock.Minute()] = true
+			p.builder.AtMinutes(clock.Minute())
+		}
+		if !p.listContinues() {
+			break
+		}
+	}
+	if len(minutes) > 1 && len(p.builder.option.Byhour) > 1 {
+		return fmt.Errorf("times with different minutes are not supported in %q", p.text)
+	}
+	p.builder.AtSeconds(0)
+	return nil
+}
+
+// list parses one or more values separated by "and"
+func (p *phraseParser) list(value func(word string) (int, bool)) ([]int, error) {
+	values := []int{}
+	for {
+		v, ok := value(p.peek())
+		if !ok {
+			return values, p.unexpected()
+		}
+		p.next()
+		values = append(values, v)
+		if !p.listContinues() {
+			return values, nil
+		}
+	}
+}
+
+// listContinues consumes the "and" before the next value of a list, an "and" that is
+// followed by something else separates the parts of the phrase
+func (p *phraseParser) listContinues() bool {
+	if p.peek() != "and" || p.pos+1 >= len(p.words) {
+		return false
+	}
+	next := p.words[p.pos+1]
+	_, ordinal := phraseOrdinal(next)
+	_, month := phraseMonth(next)
+	_, clock := time.Parse("15:04", next)
+	_, hour := strconv.Atoi(next)
+	if isPhraseWeekday(next) || ordinal || month || clock == nil || (hour == nil && !p.isCount(p.pos+2)) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// isCount returns true when the word at i follows a number of a COUNT, e.g. "10 times"
+func (p *phraseParser) isCount(i int) bool {
+	return i < len(p.words) && (p.words[i] == "times" || p.words[i] == "time")
+}
+
+// phraseOrdinal returns the position of an ordinal like "first", "last" or "15th"
+func phraseOrdinal(word string) (int, bool) {
+	if n, ok := phraseOrdinals[word]; ok {
+		return n, true
+	}
+	for _, suffix := range []string{"st", "nd", "rd", "th"} {
+		if strings.HasSuffix(word, suffix) {
+			n, err := strconv.Atoi(strings.TrimSuffix(word, suffix))
+			return n, err == nil
+		}
+	}
+	return 0, false
+}
+
+// phraseWeekday returns the weekday for a name like "monday", "mondays" or "mon"
+func phraseWeekday(word string) (RWeekday, bool) {
+	word = strings.TrimSuffix(word, "s")
+	for _, weekday := range []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday,
+		time.Friday, time.Saturday, time.Sunday} {
+		name := strings.ToLower(weekday.String())
+		if word == name || (len(word) >= 3 && strings.HasPrefix(name, word)) {
+			return RWeekday{weekday: toPyWeekday(weekday)}, true
+		}
+	}
+	return RWeekday{}, false
+}
+
+func isPhraseWeekday(word string) bool {
+	_, ok := phraseWeekday(word)
+	return ok
+}
+
+// phraseMonth returns the month for a name like "january" or "jan"
+func phraseMonth(word string) (time.Month, bool) {
+	for month := time.January; month <= time.December; month++ {
+		name := strings.ToLower(month.String())
+		if word == name || (len(word) >= 3 && strings.HasPrefix(name, word)) {
+			return month, true
+		}
+	}
+	return 0, false
+}
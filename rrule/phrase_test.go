@@ -0,0 +1,64 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseText(t *testing.T) {
+	dtstart := time.Date(2021, 7, 5, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		text  string
+		rrule string
+	}{
+		{"every weekday", "FREQ=WEEKLY;INTERVAL=1;BYDAY=MO,TU,WE,TH,FR"},
+		{"Every weekend", "FREQ=WEEKLY;INTERVAL=1;BYDAY=SA,SU"},
+		{"daily", "FREQ=DAILY;INTERVAL=1"},
+		{"every day", "FREQ=DAILY;INTERVAL=1"},
+		{"every 3 months", "FREQ=MONTHLY;INTERVAL=3"},
+		{"every other week on Tuesday and Thursday", "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH"},
+		{"every monday, wednesday and friday", "FREQ=WEEKLY;INTERVAL=1;BYDAY=MO,WE,FR"},
+		{"every other tue", "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU"},
+		{"first Monday of the month", "FREQ=MONTHLY;INTERVAL=1;BYDAY=+1MO"},
+		{"every last friday of the month at 17:30", "FREQ=MONTHLY;INTERVAL=1;BYDAY=-1FR;BYHOUR=17;BYMINUTE=30;BYSECOND=0"},
+		{"every second tuesday of the month", "FREQ=MONTHLY;INTERVAL=1;BYDAY=+2TU"},
+		{"last day of every month, 12 times", "FREQ=MONTHLY;INTERVAL=1;COUNT=12;BYMONTHDAY=-1"},
+		{"last day of the year", "FREQ=YEARLY;INTERVAL=1;BYYEARDAY=-1"},
+		{"second sunday of may", "FREQ=YEARLY;INTERVAL=1;BYMONTH=5;BYDAY=+2SU"},
+		{"every month on the 1st and 15th", "FREQ=MONTHLY;INTERVAL=1;BYMONTHDAY=1,15"},
+		{"every year in january and july on the 4th day", "FREQ=YEARLY;INTERVAL=1;BYMONTH=1,7;BYMONTHDAY=4"},
+		{"every day at 8 and 20 for 10 times", "FREQ=DAILY;INTERVAL=1;COUNT=10;BYHOUR=8,20;BYMINUTE=0;BYSECOND=0"},
+		{"every 2 weeks on monday until 2021-08-01", "FREQ=WEEKLY;INTERVAL=2;UNTIL=20210801T235959Z;BYDAY=MO"},
+		{"weekly on weekdays, once", "FREQ=WEEKLY;INTERVAL=1;COUNT=1;BYDAY=MO,TU,WE,TH,FR"},
+	}
+	for _, test := range tests {
+		r, err := ParseText(test.text, dtstart)
+		if err != nil {
+			t.Errorf("%q: %s", test.text, err)
+			continue
+		}
+		if value := r.RRuleString(); value != test.rrule {
+			t.Errorf("%q: get %v, want %v", test.text, value, test.rrule)
+		}
+	}
+}
+
+func TestParseTextInvalid(t *testing.T) {
+	for _, text := range []string{
+		"",
+		"sometimes",
+		"every",
+		"every fortnight",
+		"first monday",
+		"first monday of the week",
+		"every day at noon",
+		"every day at 9:00 and 17:30",
+		"every day until tomorrow",
+		"every month on the 32nd",
+		"every day 10",
+	} {
+		if r, err := ParseText(text, time.Now()); err == nil {
+			t.Errorf("%q: get %v, want error", text, r.RRuleString())
+		}
+	}
+}
@@ -1,32 +1,108 @@
 package rrule
 
 import (
+	"sort"
 	"time"
 )
 
 // ------------------------------------------------------------------------
 // ------------------------------------------------------------------------
 
-// NextOccurence finds the next occurence of the temporal expression starting at t
-func NextOccurence(t time.Time, te TemporalExpression) time.Time {
-	t = BeginningOfDay(t)
-	for !te.Includes(t) {
-		t = t.Add(24 * time.Hour)
+// Search finds the occurrences of a temporal expression, it steps through the times from a
+// start in steps of Resolution and gives up after Until. A step of a day (the default when
+// Resolution is zero) tests the start of every day, a smaller Resolution has to divide a day
+// and tests the times of the day from midnight on, e.g. every minute for 14:30. A zero Until
+// searches one year ahead.
+type Search struct {
+	Until      time.Time
+	Resolution time.Duration
+}
+
+// Next finds the first time at or after the step of t that is matched by the temporal
+// expression, false is returned when there is none before Until
+func (s Search) Next(t time.Time, te TemporalExpression) (time.Time, bool) {
+	until := s.Until
+	if until.IsZero() {
+		until = t.AddDate(1, 0, 0)
 	}
-	return t
+	hint, hinted := te.(CandidateHint)
+	for t = s.align(t); !t.After(until); t = s.step(t) {
+		if hinted {
+			candidate, ok := hint.NextCandidate(t)
+			if !ok {
+				return time.Time{}, false
+			}
+			if candidate.After(t) {
+				if t = s.align(candidate); t.After(until) {
+					break
+				}
+			}
+		}
+		if te.Includes(t) {
+			return t, true
+		}
+	}
+	return time.Time{}, false
 }
 
-// NextN finds the next n occurences of the temportal expression starting at t
-func NextN(t time.Time, te TemporalExpression, n int) []time.Time {
-	tt := make([]time.Time, n)
-	for i := 0; i < n; i++ {
-		t = NextOccurence(t, te)
-		tt[i] = t
-		t = t.Add(24 * time.Hour)
+// NextN finds the next n times that are matched by the temporal expression starting at t,
+// less times are returned when there are no more before Until
+func (s Search) NextN(t time.Time, te TemporalExpression, n int) []time.Time {
+	tt := []time.Time{}
+	for len(tt) < n {
+		next, ok := s.Next(t, te)
+		if !ok {
+			break
+		}
+		tt = append(tt, next)
+		t = s.step(next)
 	}
 	return tt
 }
 
+// align returns the start of the step that contains t
+func (s Search) align(t time.Time) time.Time {
+	day := BeginningOfDay(t)
+	if s.Resolution <= 0 || s.Resolution >= 24*time.Hour {
+		return day
+	}
+	return day.Add(t.Sub(day) / s.Resolution * s.Resolution)
+}
+
+// step returns the start of the step after t
+func (s Search) step(t time.Time) time.Time {
+	if s.Resolution <= 0 || s.Resolution >= 24*time.Hour {
+		return nextDay(t)
+	}
+	return s.align(t.Add(s.Resolution))
+}
+
+// NextOccurence finds the next occurence of the temporal expression starting at the day of t,
+// it searches one year ahead and returns the zero time when there is none
+func NextOccurence(t time.Time, te TemporalExpression) time.Time {
+	next, _ := Search{}.Next(t, te)
+	return next
+}
+
+// NextN finds the next n occurences of the temportal expression starting at t, less are
+// returned when there are no more within a year of the last one
+func NextN(t time.Time, te TemporalExpression, n int) []time.Time {
+	return Search{}.NextN(t, te, n)
+}
+
+// NextOccurrenceBefore finds the next day on which the temporal expression matches starting at
+// the day of t, the days are stepped through in the location of t. False is returned when
+// there is none before until.
+func NextOccurrenceBefore(t time.Time, te TemporalExpression, until time.Time) (time.Time, bool) {
+	return Search{Until: until}.Next(t, te)
+}
+
+// NextNBefore finds the next n days on which the temporal expression matches starting at t,
+// less days are returned when there are no more before until
+func NextNBefore(t time.Time, te TemporalExpression, n int, until time.Time) []time.Time {
+	return Search{Until: until}.NextN(t, te, n)
+}
+
 // ------------------------------------------------------------------------
 // ------------------------------------------------------------------------
 
@@ -37,6 +113,13 @@ type TemporalExpression interface {
 	Includes(t time.Time) bool
 }
 
+// CandidateHint is implemented by temporal expressions that know the first time at or after
+// t that they might match, a Search skips ahead to it instead of testing every step. False is
+// returned when the expression never matches at or after t.
+type CandidateHint interface {
+	NextCandidate(t time.Time) (time.Time, bool)
+}
+
 // ------------------------------------------------------------------------
 // ------------------------------------------------------------------------
 
@@ -54,11 +137,16 @@ func (a AlwaysOrNeverExpression) Includes(t time.Time) bool {
 	return a == Always
 }
 
+// NextCandidate returns t when the expression is Always and false when it is Never
+func (a AlwaysOrNeverExpression) NextCandidate(t time.Time) (time.Time, bool) {
+	return t, a == Always
+}
+
 // ------------------------------------------------------------------------
 // ------------------------------------------------------------------------
 
 // DayEventExpression is a temporal expression that matches a time-window (start - end) on
-// one day.
+// one day, a window that ends after midnight continues on the next day.
 type DayEventExpression struct {
 	Start int
 	End   int
@@ -67,7 +155,7 @@ type DayEventExpression struct {
 // Includes returns true when provided time matches the expression
 func (t DayEventExpression) Includes(dt time.Time) bool {
 	c := dt.Hour()*60 + dt.Minute()
-	return c >= t.Start && c < t.End
+	return (c >= t.Start && c < t.End) || c+24*60 < t.End
 }
 
 // DayEvents is a helper function that combines multiple DayEventExpression temporal
@@ -91,6 +179,111 @@ func DayEvent(start time.Time, end time.Time) TemporalExpression {
 // ------------------------------------------------------------------------
 // ------------------------------------------------------------------------
 
+// SpanExpression is a temporal expression that matches occurrences which start at one of the
+// Starts (offset from midnight) on a day that is matched by Days and that last for Duration,
+// an occurrence is matched on every day it covers, also past midnight and New Year. The days
+// and times are those of the location of the provided time. A Period shorter than a day, e.g.
+// an hour for an HOURLY rule, makes the Starts offsets from the start of every such period
+// and Days matches the start of an occurrence.
+type SpanExpression struct {
+	Days     TemporalExpression
+	Starts   []time.Duration
+	Duration time.Duration
+	Period   time.Duration
+}
+
+// Includes returns true when the provided time is part of an occurrence
+func (s SpanExpression) Includes(t time.Time) bool {
+	if s.Period > 0 && s.Period < 24*time.Hour {
+		return s.includesInPeriods(t)
+	}
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	// an occurrence that covers the day started at most this number of days before it
+	days := int(s.Duration/(24*time.Hour)) + 1
+	for k := 0; k <= days; k++ {
+		d := day.AddDate(0, 0, -k)
+		for _, offset := range s.Starts {
+			start := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, int(offset), d.Location())
+			if s.covers(start, t) && s.Days.Includes(start) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// includesInPeriods returns true when the provided time is part of an occurrence that starts
+// in the period of the time or in one of the periods before it
+func (s SpanExpression) includesInPeriods(t time.Time) bool {
+	// the period is taken from the offset of t, like BeginningOfHour
+	inHour := time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+	period := t.Add(-(inHour % s.Period))
+	periods := int(s.Duration/s.Period) + 1
+	for k := 0; k <= periods; k++ {
+		p := period.Add(-time.Duration(k) * s.Period)
+		for _, offset := range s.Starts {
+			start := p.Add(offset)
+			if s.covers(start, t) && s.Days.Includes(start) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// covers returns true when the occurrence at start covers the time t
+func (s SpanExpression) covers(start time.Time, t time.Time) bool {
+	if s.Duration == 0 {
+		return t.Equal(start)
+	}
+	return !t.Before(start) && t.Before(start.Add(s.Duration))
+}
+
+// Span is a helper function that creates a SpanExpression for occurrences that start at the
+// times of the day of times on the days matched by days and that last for duration
+func Span(days TemporalExpression, duration time.Duration, times ...time.Time) SpanExpression {
+	s := SpanExpression{Days: days, Duration: duration}
+	for _, t := range times {
+		s.Starts = append(s.Starts, time.Duration(t.Hour())*time.Hour+time.Duration(t.Minute())*time.Minute+time.Duration(t.Second())*time.Second)
+	}
+	return s
+}
+
+// ------------------------------------------------------------------------
+// ------------------------------------------------------------------------
+
+// TimeRangeExpression is a temporal expression that matches the times of the day from
+// Start till End (offsets from midnight), a range that ends before it starts crosses midnight
+type TimeRangeExpression struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// Includes returns true when the provided time's time of the day falls
+// between the range's Start and End values
+func (tr TimeRangeExpression) Includes(t time.Time) bool {
+	c := timeOfDay(t)
+	if tr.Start > tr.End {
+		return c >= tr.Start || c < tr.End
+	}
+	return c >= tr.Start && c < tr.End
+}
+
+// TimeRange returns a temporal expression that matches the times of the day
+// from the time of the day of start till the one of end
+func TimeRange(start, end time.Time) TimeRangeExpression {
+	return TimeRangeExpression{timeOfDay(start), timeOfDay(end)}
+}
+
+// timeOfDay returns the wall clock time of t as an offset from midnight
+func timeOfDay(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+}
+
+// ------------------------------------------------------------------------
+// ------------------------------------------------------------------------
+
 // BeforeDateExpression is a temporal expression that matches if a date is
 // before a certain set date
 type BeforeDateExpression time.Time
@@ -101,6 +294,11 @@ func (b BeforeDateExpression) Includes(t time.Time) bool {
 	return date.Before(time.Time(b))
 }
 
+// NextCandidate returns t when its day is before the date and false otherwise
+func (b BeforeDateExpression) NextCandidate(t time.Time) (time.Time, bool) {
+	return t, b.Includes(t)
+}
+
 // BeforeDate is a helper function that
 func BeforeDate(date time.Time) TemporalExpression {
 	return BeforeDateExpression(date)
@@ -118,6 +316,14 @@ func (b AfterDateExpression) Includes(t time.Time) bool {
 	return t.After(time.Time(b))
 }
 
+// NextCandidate returns the first time after the date
+func (b AfterDateExpression) NextCandidate(t time.Time) (time.Time, bool) {
+	if b.Includes(t) {
+		return t, true
+	}
+	return time.Time(b).Add(time.Nanosecond).In(t.Location()), true
+}
+
 // AfterDate is a helper function that
 func AfterDate(t time.Time, include bool) TemporalExpression {
 	date := time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 999999, t.Location())
@@ -160,7 +366,8 @@ func Days(days ...int) TemporalExpression {
 // ------------------------------------------------------------------------
 // ------------------------------------------------------------------------
 
-// DailyExpression is a temporal expression that matches a day using a start date, interval and count
+// DailyExpression is a temporal expression that matches a day using a start date, interval and
+// count, a non-zero Count limits the number of days that are matched
 type DailyExpression struct {
 	Year     int
 	Month    int
@@ -174,8 +381,8 @@ func (t DailyExpression) Includes(c time.Time) bool {
 	start := time.Date(t.Year, time.Month(t.Month), t.Day, 0, 0, 0, 0, time.UTC)
 	end := time.Date(c.Year(), c.Month(), c.Day(), 0, 0, 0, 0, time.UTC)
 	days := int(end.Sub(start).Hours() / 24)
-	count := (days + (t.Interval - 1)) / t.Interval
-	return ((days % t.Interval) == 0) && ((t.Count == 0) || (count <= t.Count))
+	count := days / t.Interval
+	return days >= 0 && ((days % t.Interval) == 0) && ((t.Count == 0) || (count < t.Count))
 }
 
 // Daily is a helper function that creates a single daily expression
@@ -240,7 +447,30 @@ func WeeksInMonth(weeks ...int) TemporalExpression {
 // ------------------------------------------------------------------------
 // ------------------------------------------------------------------------
 
-// WeeklyExpression is a temporal expression that matches a week using a start date and week interval
+// WeekNumbersExpression is a temporal expression that matches the days in one of the
+// weeks of the year (ISO 8601 numbering with weeks starting at Wkst), negative numbers
+// start at the end of the year and move backwards
+type WeekNumbersExpression struct {
+	Weeks []int
+	Wkst  time.Weekday
+}
+
+// Includes returns true when the provided time's week of the year matches the expression's
+func (w WeekNumbersExpression) Includes(t time.Time) bool {
+	mask := weekNumberMask(t.Year(), w.Weeks, toPyWeekday(w.Wkst))
+	return mask[t.YearDay()-1] == 1
+}
+
+// WeekNumbers is a helper function that creates a single WeekNumbersExpression
+func WeekNumbers(wkst time.Weekday, weeks ...int) TemporalExpression {
+	return WeekNumbersExpression{Weeks: weeks, Wkst: wkst}
+}
+
+// ------------------------------------------------------------------------
+// ------------------------------------------------------------------------
+
+// WeeklyExpression is a temporal expression that matches a week using a start date and week
+// interval, a non-zero Count limits the number of weeks that are matched
 type WeeklyExpression struct {
 	Year     int
 	Month    int
@@ -253,9 +483,10 @@ type WeeklyExpression struct {
 func (t WeeklyExpression) Includes(c time.Time) bool {
 	start := time.Date(t.Year, time.Month(t.Month), t.Day, 0, 0, 0, 0, time.UTC)
 	end := time.Date(c.Year(), c.Month(), c.Day(), 0, 0, 0, 0, time.UTC)
-	weeks := int(end.Sub(start).Hours()/24) / 7
-	count := (weeks + (t.Interval - 1)) / t.Interval
-	return ((weeks % t.Interval) == 0) && ((t.Count == 0) || (count <= t.Count))
+	days := int(end.Sub(start).Hours() / 24)
+	weeks := days / 7
+	count := weeks / t.Interval
+	return days >= 0 && ((weeks % t.Interval) == 0) && ((t.Count == 0) || (count < t.Count))
 }
 
 // Weekly is a helper function that creates a single weekly expression
@@ -267,6 +498,168 @@ func Weekly(year int, month int, day int, interval int, count int) TemporalExpre
 // ------------------------------------------------------------------------
 // ------------------------------------------------------------------------
 
+// HourlyExpression is a temporal expression that matches an hour using a start hour, interval and count
+type HourlyExpression struct {
+	Year     int
+	Month    int
+	Day      int
+	Hour     int
+	Interval int
+	Count    int
+}
+
+// Includes returns true when provided time falls in a valid hour according to hourly
+func (t HourlyExpression) Includes(c time.Time) bool {
+	start := time.Date(t.Year, time.Month(t.Month), t.Day, t.Hour, 0, 0, 0, time.UTC)
+	end := time.Date(c.Year(), c.Month(), c.Day(), c.Hour(), 0, 0, 0, time.UTC)
+	hours := int(end.Sub(start).Hours())
+	count := hours / t.Interval
+	return hours >= 0 && ((hours % t.Interval) == 0) && ((t.Count == 0) || (count < t.Count))
+}
+
+// Hourly is a helper function that creates a single hourly expression starting at the hour of start
+func Hourly(start time.Time, interval int, count int) TemporalExpression {
+	h := HourlyExpression{Year: start.Year(), Month: int(start.Month()), Day: start.Day(), Hour: start.Hour(), Interval: interval, Count: count}
+	return h
+}
+
+// ------------------------------------------------------------------------
+// ------------------------------------------------------------------------
+
+// MinutelyExpression is a temporal expression that matches a minute using a start minute, interval and count
+type MinutelyExpression struct {
+	Year     int
+	Month    int
+	Day      int
+	Hour     int
+	Minute   int
+	Interval int
+	Count    int
+}
+
+// Includes returns true when provided time falls in a valid minute according to minutely
+func (t MinutelyExpression) Includes(c time.Time) bool {
+	start := time.Date(t.Year, time.Month(t.Month), t.Day, t.Hour, t.Minute, 0, 0, time.UTC)
+	end := time.Date(c.Year(), c.Month(), c.Day(), c.Hour(), c.Minute(), 0, 0, time.UTC)
+	minutes := int(end.Sub(start).Minutes())
+	count := minutes / t.Interval
+	return minutes >= 0 && ((minutes % t.Interval) == 0) && ((t.Count == 0) || (count < t.Count))
+}
+
+// Minutely is a helper function that creates a single minutely expression starting at the minute of start
+func Minutely(start time.Time, interval int, count int) TemporalExpression {
+	m := MinutelyExpression{Year: start.Year(), Month: int(start.Month()), Day: start.Day(), Hour: start.Hour(), Minute: start.Minute(), Interval: interval, Count: count}
+	return m
+}
+
+// ------------------------------------------------------------------------
+// ------------------------------------------------------------------------
+
+// SecondlyExpression is a temporal expression that matches a second using a start second, interval and count
+type SecondlyExpression struct {
+	Year     int
+	Month    int
+	Day      int
+	Hour     int
+	Minute   int
+	Second   int
+	Interval int
+	Count    int
+}
+
+// Includes returns true when provided time falls in a valid second according to secondly
+func (t SecondlyExpression) Includes(c time.Time) bool {
+	start := time.Date(t.Year, time.Month(t.Month), t.Day, t.Hour, t.Minute, t.Second, 0, time.UTC)
+	end := time.Date(c.Year(), c.Month(), c.Day(), c.Hour(), c.Minute(), c.Second(), 0, time.UTC)
+	seconds := int(end.Sub(start).Seconds())
+	count := seconds / t.Interval
+	return seconds >= 0 && ((seconds % t.Interval) == 0) && ((t.Count == 0) || (count < t.Count))
+}
+
+// Secondly is a helper function that creates a single secondly expression starting at the second of start
+func Secondly(start time.Time, interval int, count int) TemporalExpression {
+	s := SecondlyExpression{Year: start.Year(), Month: int(start.Month()), Day: start.Day(), Hour: start.Hour(), Minute: start.Minute(), Second: start.Second(), Interval: interval, Count: count}
+	return s
+}
+
+// ------------------------------------------------------------------------
+// ------------------------------------------------------------------------
+
+// HourExpression is a temporal expression that matches an hour of the day
+type HourExpression int
+
+// Includes returns true when the provided time's hour matches the expression's
+func (h HourExpression) Includes(t time.Time) bool {
+	return t.Hour() == int(h)
+}
+
+// NextCandidate returns the start of the next hour of the day that matches
+func (h HourExpression) NextCandidate(t time.Time) (time.Time, bool) {
+	if h.Includes(t) {
+		return t, true
+	}
+	day := BeginningOfDay(t)
+	if t.Hour() > int(h) {
+		day = nextDay(day)
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), int(h), 0, 0, 0, t.Location()), true
+}
+
+// Hours is a helper function that combines multiple HourExpression
+// objects with a logical OR operation
+func Hours(hours ...int) TemporalExpression {
+	ee := make([]TemporalExpression, len(hours))
+	for i, h := range hours {
+		ee[i] = HourExpression(h)
+	}
+	return Or(ee...)
+}
+
+// ------------------------------------------------------------------------
+// ------------------------------------------------------------------------
+
+// MinuteExpression is a temporal expression that matches a minute of the hour
+type MinuteExpression int
+
+// Includes returns true when the provided time's minute matches the expression's
+func (m MinuteExpression) Includes(t time.Time) bool {
+	return t.Minute() == int(m)
+}
+
+// Minutes is a helper function that combines multiple MinuteExpression
+// objects with a logical OR operation
+func Minutes(minutes ...int) TemporalExpression {
+	ee := make([]TemporalExpression, len(minutes))
+	for i, m := range minutes {
+		ee[i] = MinuteExpression(m)
+	}
+	return Or(ee...)
+}
+
+// ------------------------------------------------------------------------
+// ------------------------------------------------------------------------
+
+// SecondExpression is a temporal expression that matches a second of the minute
+type SecondExpression int
+
+// Includes returns true when the provided time's second matches the expression's
+func (s SecondExpression) Includes(t time.Time) bool {
+	return t.Second() == int(s)
+}
+
+// Seconds is a helper function that combines multiple SecondExpression
+// objects with a logical OR operation
+func Seconds(seconds ...int) TemporalExpression {
+	ee := make([]TemporalExpression, len(seconds))
+	for i, s := range seconds {
+		ee[i] = SecondExpression(s)
+	}
+	return Or(ee...)
+}
+
+// ------------------------------------------------------------------------
+// ------------------------------------------------------------------------
+
 // WeekdayExpression is a temporal expression that matches a day of the week
 type WeekdayExpression time.Weekday
 
@@ -286,6 +679,15 @@ func (wd WeekdayExpression) Includes(t time.Time) bool {
 	return t.Weekday() == time.Weekday(wd)
 }
 
+// NextCandidate returns the start of the next day of the week that matches
+func (wd WeekdayExpression) NextCandidate(t time.Time) (time.Time, bool) {
+	if wd.Includes(t) {
+		return t, true
+	}
+	days := pymod(int(wd)-int(t.Weekday()), 7)
+	return startOfDay(t.Year(), t.Month(), t.Day()+days, t.Location()), true
+}
+
 // Weekdays is a helper function that combines multiple Weekday
 // temporal expressions using a local OR operation
 func Weekdays(weekdays ...time.Weekday) TemporalExpression {
@@ -299,6 +701,51 @@ func Weekdays(weekdays ...time.Weekday) TemporalExpression {
 // ------------------------------------------------------------------------
 // ------------------------------------------------------------------------
 
+// NthWeekdayExpression is a temporal expression that matches the n-th weekday of the month,
+// or of the year when InYear is set, negative numbers start at the end and move backwards
+type NthWeekdayExpression struct {
+	Weekday time.Weekday
+	N       int
+	InYear  bool
+}
+
+// Includes returns true when the provided time is the n-th weekday of its month (or year)
+func (nw NthWeekdayExpression) Includes(t time.Time) bool {
+	first := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1)
+	if nw.InYear {
+		first = time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+		last = time.Date(t.Year(), time.December, 31, 0, 0, 0, 0, time.UTC)
+	}
+	var day time.Time
+	if nw.N > 0 {
+		day = first.AddDate(0, 0, pymod(int(nw.Weekday)-int(first.Weekday()), 7)+(nw.N-1)*7)
+	} else if nw.N < 0 {
+		day = last.AddDate(0, 0, -pymod(int(last.Weekday())-int(nw.Weekday), 7)+(nw.N+1)*7)
+	} else {
+		return false
+	}
+	if day.Before(first) || day.After(last) {
+		return false
+	}
+	return day.YearDay() == t.YearDay() && day.Year() == t.Year()
+}
+
+// NthWeekday is a helper function that creates a single NthWeekdayExpression that
+// matches the n-th weekday of the month
+func NthWeekday(weekday time.Weekday, n int) TemporalExpression {
+	return NthWeekdayExpression{Weekday: weekday, N: n}
+}
+
+// NthWeekdayOfYear is a helper function that creates a single NthWeekdayExpression that
+// matches the n-th weekday of the year
+func NthWeekdayOfYear(weekday time.Weekday, n int) TemporalExpression {
+	return NthWeekdayExpression{Weekday: weekday, N: n, InYear: true}
+}
+
+// ------------------------------------------------------------------------
+// ------------------------------------------------------------------------
+
 // WeekdayRangeExpression is a temporal expression that matches all
 // days between the Start and End values
 type WeekdayRangeExpression struct {
@@ -331,21 +778,17 @@ type DateRangeExpression struct {
 	EndDay     int
 }
 
-// Includes returns true when the provided time's weekday falls
-// between the range's Start and Stop values
+// Includes returns true when the provided time's day falls
+// between the range's Start and End values, a range that ends
+// before it starts crosses New Year
 func (dr DateRangeExpression) Includes(t time.Time) bool {
-	m := int(t.Month())
-	d := t.Day()
-	if m == dr.StartMonth && m == dr.EndMonth {
-		return d >= dr.StartDay && d <= dr.EndDay
-	} else if m > dr.StartMonth && m < dr.EndMonth {
-		return true
-	} else if m == dr.StartMonth {
-		return d >= dr.StartDay
-	} else if m == dr.EndMonth {
-		return d <= dr.EndDay
+	c := int(t.Month())*100 + t.Day()
+	start := dr.StartMonth*100 + dr.StartDay
+	end := dr.EndMonth*100 + dr.EndDay
+	if start > end {
+		return c >= start || c <= end
 	}
-	return false
+	return c >= start && c <= end
 }
 
 // DateRange returns a temporal expression that matches all
@@ -381,6 +824,18 @@ func (m MonthExpression) Includes(t time.Time) bool {
 	return t.Month() == time.Month(m)
 }
 
+// NextCandidate returns the start of the next month that matches
+func (m MonthExpression) NextCandidate(t time.Time) (time.Time, bool) {
+	if m.Includes(t) {
+		return t, true
+	}
+	year := t.Year()
+	if t.Month() > time.Month(m) {
+		year++
+	}
+	return startOfDay(year, time.Month(m), 1, t.Location()), true
+}
+
 // Months is a helper function that combines multiple Month temporal
 // expressions using a local OR operation
 func Months(months ...time.Month) TemporalExpression {
@@ -394,7 +849,8 @@ func Months(months ...time.Month) TemporalExpression {
 // ------------------------------------------------------------------------
 // ------------------------------------------------------------------------
 
-// MonthlyExpression is a temporal expression that matches a week using a start date and week interval
+// MonthlyExpression is a temporal expression that matches a month using a start month and month
+// interval, a non-zero Count limits the number of months that are matched
 type MonthlyExpression struct {
 	Year     int
 	Month    int
@@ -413,7 +869,7 @@ func (m MonthlyExpression) Includes(c time.Time) bool {
 	}
 	months := (month + ((year - m.Year) * 12)) - m.Month
 	count := months / m.Interval
-	return ((months % m.Interval) == 0) && ((m.Count == 0) || (count <= m.Count))
+	return ((months % m.Interval) == 0) && ((m.Count == 0) || (count < m.Count))
 }
 
 // Monthly is a helper function that creates a single monthly expression
@@ -457,6 +913,14 @@ func (y YearExpression) Includes(t time.Time) bool {
 	return t.Year() == int(y)
 }
 
+// NextCandidate returns the start of the year when it is still to come and false when it has passed
+func (y YearExpression) NextCandidate(t time.Time) (time.Time, bool) {
+	if t.Year() < int(y) {
+		return startOfDay(int(y), time.January, 1, t.Location()), true
+	}
+	return t, y.Includes(t)
+}
+
 // Years is a helper function that combines multipe YearExpression
 // objects using a local OR operation
 func Years(years ...int) TemporalExpression {
@@ -470,7 +934,56 @@ func Years(years ...int) TemporalExpression {
 // ------------------------------------------------------------------------
 // ------------------------------------------------------------------------
 
-// YearlyExpression is a temporal expression that matches a year using a start, interval and count
+// YearDayExpression is a temporal expression that matches a day of the year starting at 1
+// negative numbers start at the end of the year and move backwards
+type YearDayExpression int
+
+// Includes returns true when the provided time's day of the year matches the expression's
+func (d YearDayExpression) Includes(t time.Time) bool {
+	day := int(d)
+	if day < 0 {
+		day = 365 + isLeap(t.Year()) + day + 1
+	}
+	return t.YearDay() == day
+}
+
+// YearDays is a helper function that combines multiple YearDayExpression
+// objects with a logical OR operation
+func YearDays(days ...int) TemporalExpression {
+	ee := make([]TemporalExpression, len(days))
+	for i, d := range days {
+		ee[i] = YearDayExpression(d)
+	}
+	return Or(ee...)
+}
+
+// ------------------------------------------------------------------------
+// ------------------------------------------------------------------------
+
+// EasterExpression is a temporal expression that matches a day relative to easter sunday
+type EasterExpression int
+
+// Includes returns true when the provided time is the expression's number of days from easter
+func (e EasterExpression) Includes(t time.Time) bool {
+	day := easter(t.Year()).AddDate(0, 0, int(e))
+	return day.YearDay() == t.YearDay() && day.Year() == t.Year()
+}
+
+// Easter is a helper function that combines multiple EasterExpression
+// objects with a logical OR operation
+func Easter(offsets ...int) TemporalExpression {
+	ee := make([]TemporalExpression, len(offsets))
+	for i, o := range offsets {
+		ee[i] = EasterExpression(o)
+	}
+	return Or(ee...)
+}
+
+// ------------------------------------------------------------------------
+// ------------------------------------------------------------------------
+
+// YearlyExpression is a temporal expression that matches a year using a start, interval and
+// count, a non-zero Count limits the number of years that are matched
 type YearlyExpression struct {
 	Year     int
 	Interval int
@@ -487,7 +1000,7 @@ func (m YearlyExpression) Includes(c time.Time) bool {
 	}
 	years := (year - m.Year)
 	count := years / m.Interval
-	return ((years % m.Interval) == 0) && ((m.Count == 0) || (count <= m.Count))
+	return ((years % m.Interval) == 0) && ((m.Count == 0) || (count < m.Count))
 }
 
 // Yearly is a helper function that creates a single yearly expression
@@ -519,6 +1032,14 @@ func (yr YearRangeExpression) Includes(t time.Time) bool {
 	return int(yr.Start) <= year && year <= int(yr.End)
 }
 
+// NextCandidate returns the start of the range when it is still to come and false when it has passed
+func (yr YearRangeExpression) NextCandidate(t time.Time) (time.Time, bool) {
+	if t.Year() < int(yr.Start) {
+		return startOfDay(int(yr.Start), time.January, 1, t.Location()), true
+	}
+	return t, t.Year() <= int(yr.End)
+}
+
 // ------------------------------------------------------------------------
 // ------------------------------------------------------------------------
 
@@ -533,6 +1054,16 @@ func (d DateExpression) Includes(t time.Time) bool {
 	return y0 == y1 && m0 == m1 && d0 == d1
 }
 
+// NextCandidate returns the start of the date when it is still to come and false when it has passed
+func (d DateExpression) NextCandidate(t time.Time) (time.Time, bool) {
+	if d.Includes(t) {
+		return t, true
+	}
+	date := time.Time(d)
+	start := startOfDay(date.Year(), date.Month(), date.Day(), date.Location())
+	return start, start.After(t)
+}
+
 // Dates is a helper function that combines multiple DateExpression
 // objects using a logical OR operation
 func Dates(dates ...time.Time) TemporalExpression {
@@ -546,6 +1077,73 @@ func Dates(dates ...time.Time) TemporalExpression {
 // ------------------------------------------------------------------------
 // ------------------------------------------------------------------------
 
+// SetPosExpression is a temporal expression that matches the n-th candidates (BYSETPOS) of
+// the period of the frequency that contains the provided time. The candidates are the days
+// of the period that are matched by Days combined with the Times, which are offsets from the
+// start of the day (DAILY and longer) or of the hour, minute or second (sub-daily). A time
+// matches a candidate when both are in the same Resolution slot.
+type SetPosExpression struct {
+	Freq       Frequency
+	Wkst       time.Weekday
+	Positions  []int
+	Days       TemporalExpression
+	Times      []time.Duration
+	Resolution time.Duration
+}
+
+// Includes returns true when the provided time matches one of the selected candidates
+func (sp SetPosExpression) Includes(t time.Time) bool {
+	// Work on the wall clock so that daylight saving time doesn't move the candidates
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	first, n := day, 1
+	switch sp.Freq {
+	case YEARLY:
+		first = time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+		n = 365 + isLeap(t.Year())
+	case MONTHLY:
+		first = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+		n = daysIn(t.Month(), t.Year())
+	case WEEKLY:
+		first = day.AddDate(0, 0, -pymod(int(t.Weekday())-int(sp.Wkst), 7))
+		n = 7
+	}
+
+	candidates := []time.Time{}
+	for i := 0; i < n; i++ {
+		d := first.AddDate(0, 0, i)
+		if !sp.Days.Includes(d) {
+			continue
+		}
+		switch sp.Freq {
+		case HOURLY:
+			d = d.Add(time.Duration(t.Hour()) * time.Hour)
+		case MINUTELY:
+			d = d.Add(time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute)
+		case SECONDLY:
+			d = wall
+		}
+		for _, offset := range sp.Times {
+			candidates = append(candidates, d.Add(offset))
+		}
+	}
+	sort.Sort(timeSlice(candidates))
+
+	for _, pos := range sp.Positions {
+		i := pos - 1
+		if pos < 0 {
+			i = len(candidates) + pos
+		}
+		if i >= 0 && i < len(candidates) && candidates[i].Truncate(sp.Resolution).Equal(wall.Truncate(sp.Resolution)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ------------------------------------------------------------------------
+// ------------------------------------------------------------------------
+
 // Or combines multiple temporal expressions into one using
 // a local Or operation
 func Or(ee ...TemporalExpression) OrExpression {
@@ -555,18 +1153,18 @@ func Or(ee ...TemporalExpression) OrExpression {
 // OrExpression is a temporal expression consisting of multiple
 // temporal expressions combined using a logical OR operation
 type OrExpression struct {
-	ee []TemporalExpression
+	Expressions []TemporalExpression
 }
 
 // Or adds a temporal expression
 func (oe *OrExpression) Or(e TemporalExpression) {
-	oe.ee = append(oe.ee, e)
+	oe.Expressions = append(oe.Expressions, e)
 }
 
 // Includes returns true when any of the underlying expressions
 // match the provided time
 func (oe OrExpression) Includes(t time.Time) bool {
-	for _, e := range oe.ee {
+	for _, e := range oe.Expressions {
 		if e.Includes(t) {
 			return true
 		}
@@ -574,6 +1172,24 @@ func (oe OrExpression) Includes(t time.Time) bool {
 	return false
 }
 
+// NextCandidate returns the earliest candidate of the underlying expressions, t when one of
+// them has no hint and false when none of them matches again
+func (oe OrExpression) NextCandidate(t time.Time) (time.Time, bool) {
+	var next time.Time
+	found := false
+	for _, e := range oe.Expressions {
+		hint, ok := e.(CandidateHint)
+		if !ok {
+			return t, true
+		}
+		candidate, ok := hint.NextCandidate(t)
+		if ok && (!found || candidate.Before(next)) {
+			next, found = candidate, true
+		}
+	}
+	return next, found
+}
+
 // ------------------------------------------------------------------------
 // ------------------------------------------------------------------------
 
@@ -586,18 +1202,18 @@ func And(ee ...TemporalExpression) AndExpression {
 // AndExpression is a temporal expressions consisting of mutliple
 // temporal expressions combined with a local AND operation
 type AndExpression struct {
-	ee []TemporalExpression
+	Expressions []TemporalExpression
 }
 
 // And adds a temporal expression
 func (ae *AndExpression) And(e TemporalExpression) {
-	ae.ee = append(ae.ee, e)
+	ae.Expressions = append(ae.Expressions, e)
 }
 
 // Includes return true when all the underlying temporal expressions
 // match the provided time
 func (ae AndExpression) Includes(t time.Time) bool {
-	for _, e := range ae.ee {
+	for _, e := range ae.Expressions {
 		if !e.Includes(t) {
 			return false
 		}
@@ -605,6 +1221,24 @@ func (ae AndExpression) Includes(t time.Time) bool {
 	return true
 }
 
+// NextCandidate returns the latest candidate of the underlying expressions and false when one
+// of them never matches again
+func (ae AndExpression) NextCandidate(t time.Time) (time.Time, bool) {
+	next := t
+	for _, e := range ae.Expressions {
+		if hint, ok := e.(CandidateHint); ok {
+			candidate, ok := hint.NextCandidate(t)
+			if !ok {
+				return time.Time{}, false
+			}
+			if candidate.After(next) {
+				next = candidate
+			}
+		}
+	}
+	return next, true
+}
+
 // ------------------------------------------------------------------------
 // ------------------------------------------------------------------------
 
@@ -616,11 +1250,45 @@ func Not(e TemporalExpression) NotExpression {
 // NotExpression is a temporal expression with negates
 // its underlying expression
 type NotExpression struct {
-	e TemporalExpression
+	Expression TemporalExpression
 }
 
 // Includes returns true when the underlying temporal expression
 // does not match the provided time
 func (ne NotExpression) Includes(t time.Time) bool {
-	return !ne.e.Includes(t)
+	return !ne.Expression.Includes(t)
+}
+
+// ------------------------------------------------------------------------
+// ------------------------------------------------------------------------
+
+// Except is a helper function that excludes the days of the dates from a temporal expression
+func Except(e TemporalExpression, dates ...time.Time) ExceptExpression {
+	return ExceptExpression{Expression: e, Dates: dates}
+}
+
+// ExceptExpression is a temporal expression that matches its underlying expression
+// except on the days of the Dates, a day is compared in the location of its date
+type ExceptExpression struct {
+	Expression TemporalExpression
+	Dates      []time.Time
+}
+
+// Includes returns true when the underlying temporal expression matches the
+// provided time and its day is not one of the dates
+func (ee ExceptExpression) Includes(t time.Time) bool {
+	for _, date := range ee.Dates {
+		if DateExpression(date).Includes(t) {
+			return false
+		}
+	}
+	return ee.Expression.Includes(t)
+}
+
+// NextCandidate returns the candidate of the underlying expression
+func (ee ExceptExpression) NextCandidate(t time.Time) (time.Time, bool) {
+	if hint, ok := ee.Expression.(CandidateHint); ok {
+		return hint.NextCandidate(t)
+	}
+	return t, true
 }
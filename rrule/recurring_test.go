@@ -0,0 +1,119 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+)
+
+// countingExpression matches every time and counts how often it is asked
+type countingExpression struct {
+	count *int
+}
+
+func (c countingExpression) Includes(t time.Time) bool {
+	*c.count++
+	return true
+}
+
+func TestSearchNever(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	if value, ok := (Search{}).Next(start, Never); ok {
+		t.Errorf("get %v, want no occurrence", value)
+	}
+	if value, ok := NextOccurrenceBefore(start, Weekdays(), start.AddDate(10, 0, 0)); ok {
+		t.Errorf("get %v, want no occurrence", value)
+	}
+	ended := And(BeforeDate(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)), Weekdays(time.Monday))
+	value := NextNBefore(start, ended, 10, start.AddDate(1, 0, 0))
+	if len(value) != 4 || !value[3].Equal(time.Date(2026, 1, 26, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("get %v, want the 4 Mondays of January", value)
+	}
+}
+
+func TestNextOccurence(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	if value := NextOccurence(start, Weekdays(time.Monday)); !value.Equal(time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("get %v, want the first Monday", value)
+	}
+	if value := NextOccurence(start, Never); !value.IsZero() {
+		t.Errorf("get %v, want the zero time", value)
+	}
+	value := NextN(start, Weekdays(time.Monday), 2)
+	want := []time.Time{time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)}
+	if !timesEqual(value, want) {
+		t.Errorf("get %v, want %v", value, want)
+	}
+}
+
+func TestSearchResolution(t *testing.T) {
+	amsterdam, _ := time.LoadLocation("Europe/Amsterdam")
+	meeting := And(Weekdays(time.Tuesday), Hours(14), Minutes(30))
+	start := time.Date(2026, 3, 25, 16, 0, 0, 0, amsterdam)
+	until := start.AddDate(0, 1, 0)
+
+	if _, ok := NextOccurrenceBefore(start, meeting, until); ok {
+		t.Error("expected no day start to match the meeting")
+	}
+	value := Search{Until: until, Resolution: time.Minute}.NextN(start, meeting, 2)
+	want := []time.Time{
+		time.Date(2026, 3, 31, 14, 30, 0, 0, amsterdam),
+		time.Date(2026, 4, 7, 14, 30, 0, 0, amsterdam),
+	}
+	if !timesEqual(value, want) {
+		t.Errorf("get %v, want %v", value, want)
+	}
+	value = Search{Until: until, Resolution: time.Hour}.NextN(start, And(Weekdays(time.Sunday), Hours(3)), 1)
+	want = []time.Time{time.Date(2026, 3, 29, 3, 0, 0, 0, amsterdam)}
+	if !timesEqual(value, want) {
+		t.Errorf("get %v, want %v", value, want)
+	}
+}
+
+func TestSearchSkipsAhead(t *testing.T) {
+	count := 0
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	e := And(countingExpression{&count}, Or(YearExpression(2030), YearExpression(2031)), Months(time.June), Weekdays(time.Friday))
+	value, ok := Search{Until: start.AddDate(10, 0, 0), Resolution: time.Minute}.Next(start, e)
+	want := time.Date(2030, 6, 7, 0, 0, 0, 0, time.UTC)
+	if !ok || !value.Equal(want) {
+		t.Errorf("get %v, want %v", value, want)
+	}
+	if count > 5 {
+		t.Errorf("get %d tested times, want the search to skip ahead", count)
+	}
+	if _, ok := (Search{Until: start.AddDate(100, 0, 0)}).Next(start, And(e, YearExpression(2025))); ok {
+		t.Error("expected no occurrence after the year has passed")
+	}
+}
+
+func TestPeriodCount(t *testing.T) {
+	start := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	periods := map[string]TemporalExpression{
+		"daily":   Daily(2026, 1, 5, 2, 3),
+		"weekly":  Weekly(2026, 1, 5, 2, 3),
+		"monthly": Monthly(2026, 1, 2, 3),
+		"yearly":  Yearly(2026, 2, 3),
+	}
+	for name, e := range periods {
+		matched := map[string]bool{}
+		for day := start.AddDate(0, 0, -10); day.Year() < 2034; day = day.AddDate(0, 0, 1) {
+			if !e.Includes(day) {
+				continue
+			}
+			switch name {
+			case "daily":
+				matched[day.Format("2006-01-02")] = true
+			case "weekly":
+				year, week := day.ISOWeek()
+				matched[time.Date(year, 1, week, 0, 0, 0, 0, time.UTC).Format("2006-01-02")] = true
+			case "monthly":
+				matched[day.Format("2006-01")] = true
+			case "yearly":
+				matched[day.Format("2006")] = true
+			}
+		}
+		if len(matched) != 3 {
+			t.Errorf("%s: get %d periods, want 3", name, len(matched))
+		}
+	}
+}
@@ -3,6 +3,7 @@ package rrule
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 )
 
@@ -88,13 +89,15 @@ var (
 
 // ROption offers options to construct a RRule instance
 type ROption struct {
-	Code       string
-	Freq       Frequency
-	Dtstart    time.Time
-	Interval   int
-	Wkst       RWeekday
-	Count      int
-	Until      time.Time
+	Code     string
+	Freq     Frequency
+	Dtstart  time.Time
+	Interval int
+	Wkst     RWeekday
+	Count    int
+	Until    time.Time
+	// UntilDate is true when UNTIL is a DATE, Until is then the last second of that day
+	UntilDate  bool
 	Bysetpos   []int
 	Bymonth    []int
 	Bymonthday []int
@@ -130,8 +133,10 @@ type RRule struct {
 	byminute                []int
 	bysecond                []int
 	byeaster                []int
+	timeset                 []time.Time
 	len                     int
 	compiled                TemporalExpression
+	duration                time.Duration
 }
 
 // NOTE
@@ -147,10 +152,10 @@ func NewRRule(arg ROption) (*RRule, error) {
 	r := RRule{}
 	r.OrigOptions = arg
 	if arg.Dtstart.IsZero() {
-		arg.Dtstart = time.Now()
+		arg.Dtstart = time.Now().UTC()
 	}
 	arg.Dtstart = arg.Dtstart.Truncate(time.Second)
-	r.dtstart = arg.Dtstart.Local()
+	r.dtstart = arg.Dtstart
 	r.freq = arg.Freq
 	if arg.Interval == 0 {
 		r.interval = 1
@@ -162,7 +167,7 @@ func NewRRule(arg ROption) (*RRule, error) {
 		// add largest representable duration (approximately 290 years).
 		arg.Until = r.dtstart.Add(time.Duration(1<<63 - 1))
 	}
-	r.until = arg.Until.Local()
+	r.until = arg.Until
 	r.wkst = arg.Wkst.weekday
 	r.bysetpos = arg.Bysetpos
 	if len(arg.Byweekno) == 0 &&
@@ -222,11 +227,29 @@ func NewRRule(arg ROption) (*RRule, error) {
 	}
 
 	r.Options = arg
+	r.calculateTimeset()
 	r.compiled = Never
 
 	return &r, nil
 }
 
+// calculateTimeset calculates the times of the day at which the rule occurs, this
+// is only used for the frequencies that are not sub-daily
+func (r *RRule) calculateTimeset() {
+	if r.freq >= HOURLY {
+		return
+	}
+	r.timeset = []time.Time{}
+	for _, hour := range r.byhour {
+		for _, minute := range r.byminute {
+			for _, second := range r.bysecond {
+				r.timeset = append(r.timeset, time.Date(1, 1, 1, hour, minute, second, 0, r.dtstart.Location()))
+			}
+		}
+	}
+	sort.Sort(timeSlice(r.timeset))
+}
+
 // validateBounds checks the RRule's options are within the boundaries defined
 // in RRFC 5545. This is useful to ensure that the RRule can even have any times,
 // as going outside these bounds trivially will never have any dates. This can catch
@@ -282,26 +305,59 @@ func validateBounds(arg ROption) error {
 	return nil
 }
 
-// Just to satisfy the test suite
+// All returns all occurrences of the rule
 func (r *RRule) All() []time.Time {
-	return []time.Time{}
+	return all(r.Iterator())
 }
-func (r *RRule) Until(dt time.Time) []time.Time {
-	return []time.Time{}
+
+// Between returns all the occurrences of the rule between after and before.
+// The inc keyword defines what happens if after and/or before are themselves occurrences.
+// With inc == True, they will be included in the list, if they are found in the recurrence set.
+func (r *RRule) Between(after, before time.Time, inc bool) []time.Time {
+	return between(r.Iterator(), after, before, inc)
 }
-func (r *RRule) Before(dt time.Time, include bool) time.Time {
-	return time.Time{}
+
+// Before returns the last recurrence before the given datetime instance,
+// or time.Time's zero value if no recurrence match.
+// The inc keyword defines what happens if dt is an occurrence.
+// With inc == True, if dt itself is an occurrence, it will be returned.
+func (r *RRule) Before(dt time.Time, inc bool) time.Time {
+	return before(r.Iterator(), dt, inc)
 }
-func (r *RRule) After(dt time.Time, include bool) time.Time {
-	return time.Time{}
+
+// After returns the first recurrence after the given datetime instance,
+// or time.Time's zero value if no recurrence match.
+// The inc keyword defines what happens if dt is an occurrence.
+// With inc == True, if dt itself is an occurrence, it will be returned.
+func (r *RRule) After(dt time.Time, inc bool) time.Time {
+	return after(r.Iterator(), dt, inc)
 }
-func (r *RRule) Between(dts time.Time, dte time.Time, include bool) []time.Time {
-	return []time.Time{}
+
+// OccurrencesUntil returns all the occurrences of the rule up to and including the given
+// datetime instance
+func (r *RRule) OccurrencesUntil(dt time.Time) []time.Time {
+	return r.Between(r.dtstart, dt, true)
+}
+
+// Until sets a new UNTIL for the rule
+func (r *RRule) Until(ut time.Time) {
+	r.until = ut
+	r.Options.Until = ut
+	r.OrigOptions.Until = ut
+	r.Options.UntilDate = false
+	r.OrigOptions.UntilDate = false
+}
+
+// GetUntil returns the UNTIL of the rule
+func (r *RRule) GetUntil() time.Time {
+	return r.until
 }
 
+// DTStart sets a new DTSTART for the rule and recalculates the timeset if needed
 func (r *RRule) DTStart(dt time.Time) {
 	r.dtstart = dt.Truncate(time.Second)
 	r.Options.Dtstart = r.dtstart
+	r.OrigOptions.Dtstart = r.dtstart
 
 	if len(r.Options.Byhour) == 0 && r.freq < HOURLY {
 		r.byhour = []int{r.dtstart.Hour()}
@@ -312,33 +368,208 @@ func (r *RRule) DTStart(dt time.Time) {
 	if len(r.Options.Bysecond) == 0 && r.freq < SECONDLY {
 		r.bysecond = []int{r.dtstart.Second()}
 	}
+	r.calculateTimeset()
+}
+
+// GetDTStart returns the DTSTART of the rule
+func (r *RRule) GetDTStart() time.Time {
+	return r.dtstart
 }
 
 // NOTE: There is another good package on github for event recurrence: https://github.com/boombuler/recurrence
 
 // Compile will convert the RRule information into a TemporalExpression structure
 func (r *RRule) Compile(start time.Time, end time.Time) error {
-	if r.freq == YEARLY {
-		//fmt.Printf("Compiled RRule '%s' into temporal yearly expression (%v - %v)\n", r.String(), start, end)
-		// Check for day events, like birthdays ...
-		r.compiled = And(AfterDate(r.dtstart, true), Yearly(start.Year(), r.interval, r.count), DateRange(start, end))
-		return nil
-	} else if r.freq == MONTHLY {
-		//fmt.Printf("Compiled RRule '%s' into temporal monthly expression (%v - %v)\n", r.String(), start, end)
-		r.compiled = And(AfterDate(r.dtstart, true), Monthly(start.Year(), int(start.Month()), r.interval, r.count), BeforeDate(end))
-		return nil
-	} else if r.freq == DAILY {
-		//fmt.Printf("Compiled RRule '%s' into temporal daily expression (%v - %v)\n", r.String(), start, end)
-		r.compiled = And(Daily(start.Year(), int(start.Month()), start.Day(), r.interval, r.count))
-		r.compiled = And(r.compiled, DayEvent(start, end))
-		return nil
+	// the periods are not limited, the COUNT of the rule limits its instances, see countLimit
+	var period TemporalExpression
+	switch r.freq {
+	case YEARLY:
+		period = Yearly(r.dtstart.Year(), r.interval, 0)
+	case MONTHLY:
+		period = Monthly(r.dtstart.Year(), int(r.dtstart.Month()), r.interval, 0)
+	case WEEKLY:
+		// the weeks are counted from the week start (WKST) of the week that contains dtstart
+		weekStart := r.dtstart.AddDate(0, 0, -pymod(toPyWeekday(r.dtstart.Weekday())-r.wkst, 7))
+		period = Weekly(weekStart.Year(), int(weekStart.Month()), weekStart.Day(), r.interval, 0)
+	case DAILY:
+		period = Daily(r.dtstart.Year(), int(r.dtstart.Month()), r.dtstart.Day(), r.interval, 0)
+	case HOURLY:
+		period = Hourly(r.dtstart, r.interval, 0)
+	case MINUTELY:
+		period = Minutely(r.dtstart, r.interval, 0)
+	case SECONDLY:
+		period = Secondly(r.dtstart, r.interval, 0)
+	default:
+		r.compiled = Never
+		return errors.New(fmt.Sprintf("Failed to compile RRule '%s' into temporal expression", r.String()))
 	}
 
-	r.compiled = Never
-	return errors.New(fmt.Sprintf("Failed to compile RRule '%s' into temporal expression", r.String()))
+	r.duration = end.Sub(start)
+	// the periods are counted from dtstart, start and end are those of the first instance and
+	// only give the duration of every instance
+	compiled := And(AfterDate(r.dtstart, true), period, Not(AfterDateExpression(r.until)))
+	if r.count != 0 {
+		compiled.And(r.countLimit())
+	}
+	days := r.dayFilters()
+	for _, e := range days {
+		compiled.And(e)
+	}
+	switch r.freq {
+	case HOURLY, MINUTELY, SECONDLY:
+		if len(r.byhour) != 0 {
+			compiled.And(Hours(r.byhour...))
+		}
+		if len(r.Options.Byminute) != 0 {
+			compiled.And(Minutes(r.Options.Byminute...))
+		}
+		if len(r.Options.Bysecond) != 0 {
+			compiled.And(Seconds(r.Options.Bysecond...))
+		}
+	}
+	if len(r.bysetpos) != 0 {
+		compiled.And(r.setPos(days))
+	}
+	// the rule selects the start of an occurrence, which is matched for as long as it lasts
+	times, length := r.periodTimes()
+	r.compiled = SpanExpression{Days: compiled, Starts: times, Duration: r.duration, Period: length}
+	return nil
+}
+
+// countLimit returns the temporal expression that ends the rule after COUNT instances, the
+// instances are generated to find the first one that is not counted anymore, so that a period
+// with several instances (BY* parts) and a dtstart that is not an instance are counted right
+func (r *RRule) countLimit() TemporalExpression {
+	option := r.options()
+	option.Count = r.count + 1
+	rule, err := NewRRule(option)
+	if err != nil {
+		return Always
+	}
+	all := rule.All()
+	if len(all) <= r.count {
+		return Always
+	}
+	// only the times before the first instance that is not counted are matched
+	return Not(AfterDateExpression(all[r.count].Add(-time.Nanosecond)))
 }
 
-// Includes will determine if 'dt' is regarded by the TemporalExpression as included
+// dayFilters returns the temporal expressions for the BY* parts that select days
+func (r *RRule) dayFilters() (filters []TemporalExpression) {
+	if len(r.bymonth) != 0 {
+		months := make([]time.Month, len(r.bymonth))
+		for i, month := range r.bymonth {
+			months[i] = time.Month(month)
+		}
+		filters = append(filters, Months(months...))
+	}
+	if len(r.byweekno) != 0 {
+		filters = append(filters, WeekNumbers(time.Weekday((r.wkst+1)%7), r.byweekno...))
+	}
+	if len(r.byweekday) != 0 {
+		filters = append(filters, Weekdays(r.weekdays()...))
+	}
+	if len(r.bynweekday) != 0 {
+		// the n-th weekday is counted in the year unless a YEARLY rule is limited to months
+		inYear := r.freq == YEARLY && len(r.bymonth) == 0
+		nth := make([]TemporalExpression, len(r.bynweekday))
+		for i, wday := range r.bynweekday {
+			nth[i] = NthWeekdayExpression{Weekday: time.Weekday((wday.weekday + 1) % 7), N: wday.n, InYear: inYear}
+		}
+		filters = append(filters, Or(nth...))
+	}
+	if len(r.byeaster) != 0 {
+		filters = append(filters, Easter(r.byeaster...))
+	}
+	if len(r.bymonthday) != 0 || len(r.bynmonthday) != 0 {
+		filters = append(filters, Days(append(append([]int{}, r.bymonthday...), r.bynmonthday...)...))
+	}
+	if len(r.byyearday) != 0 {
+		filters = append(filters, YearDays(r.byyearday...))
+	}
+	return filters
+}
+
+// periodTimes returns the start times of the instances as offsets from the start of their
+// period, that is the hour for HOURLY, the minute for MINUTELY, the second for SECONDLY and
+// the day otherwise, and the length of the period
+func (r *RRule) periodTimes() (times []time.Duration, period time.Duration) {
+	switch r.freq {
+	case HOURLY:
+		for _, minute := range r.byminute {
+			for _, second := range r.bysecond {
+				times = append(times, time.Duration(minute)*time.Minute+time.Duration(second)*time.Second)
+			}
+		}
+		return times, time.Hour
+	case MINUTELY:
+		for _, second := range r.bysecond {
+			times = append(times, time.Duration(second)*time.Second)
+		}
+		return times, time.Minute
+	case SECONDLY:
+		return []time.Duration{0}, time.Second
+	}
+	for _, ts := range r.timeset {
+		times = append(times, time.Duration(ts.Hour())*time.Hour+time.Duration(ts.Minute())*time.Minute+time.Duration(ts.Second())*time.Second)
+	}
+	return times, 24 * time.Hour
+}
+
+// setPos returns the temporal expression for BYSETPOS, the candidates are the days that are
+// matched by the day filters combined with the times of the period
+func (r *RRule) setPos(days []TemporalExpression) TemporalExpression {
+	sp := SetPosExpression{Freq: r.freq, Wkst: time.Weekday((r.wkst + 1) % 7), Positions: r.bysetpos, Days: And(days...)}
+	sp.Times, sp.Resolution = r.periodTimes()
+	// sub-daily candidates are compared at the finest unit of the rule
+	if r.freq == HOURLY && len(r.Options.Byminute) != 0 {
+		sp.Resolution = time.Minute
+	}
+	if (r.freq == HOURLY || r.freq == MINUTELY) && len(r.Options.Bysecond) != 0 {
+		sp.Resolution = time.Second
+	}
+	return sp
+}
+
+// weekdays returns the BYDAY weekdays of the rule as time.Weekday values
+func (r *RRule) weekdays() []time.Weekday {
+	weekdays := make([]time.Weekday, len(r.byweekday))
+	for i, wday := range r.byweekday {
+		weekdays[i] = time.Weekday((wday + 1) % 7)
+	}
+	return weekdays
+}
+
+// Includes will determine if 'dt' is regarded by the TemporalExpression as included, the days
+// and times of the rule are those of the location of its DTSTART
 func (r *RRule) Includes(dt time.Time) bool {
-	return r.compiled.Includes(dt)
+	return r.compiled.Includes(dt.In(r.dtstart.Location()))
+}
+
+// Duration sets the duration of the occurrences that is used by OccursOn and OccursAt,
+// Compile sets it to the time from start till end
+func (r *RRule) Duration(duration time.Duration) {
+	r.duration = duration
+}
+
+// OccursOn returns true when an occurrence takes place (partly) on the day of 'day' in its location
+func (r *RRule) OccursOn(day time.Time) bool {
+	_, ok := occurrenceOn(r.Iterator(), day, r.duration)
+	return ok
+}
+
+// OccurrenceOn returns the start of the first occurrence that takes place on the day of 'day'
+func (r *RRule) OccurrenceOn(day time.Time) (time.Time, bool) {
+	return occurrenceOn(r.Iterator(), day, r.duration)
+}
+
+// OccursAt returns true when an occurrence is in progress at the instant 'dt'
+func (r *RRule) OccursAt(dt time.Time) bool {
+	_, ok := occurrenceAt(r.Iterator(), dt, r.duration)
+	return ok
+}
+
+// OccurrenceAt returns the start of the occurrence that is in progress at the instant 'dt'
+func (r *RRule) OccurrenceAt(dt time.Time) (time.Time, bool) {
+	return occurrenceAt(r.Iterator(), dt, r.duration)
 }
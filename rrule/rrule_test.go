@@ -1,6 +1,7 @@
 package rrule
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -10,7 +11,7 @@ func timesEqual(value, want []time.Time) bool {
 		return false
 	}
 	for index := range value {
-		if value[index] != want[index] {
+		if !value[index].Equal(want[index]) {
 			return false
 		}
 	}
@@ -239,15 +240,20 @@ func TestNoAfter(t *testing.T) {
 		Count:   5,
 		Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)})
 	want := time.Time{}
-	end := time.Date(1997, 12, 31, 9, 0, 0, 0, time.UTC)
+	end := time.Date(1997, 9, 2, 10, 0, 0, 0, time.UTC)
 	err := r.Compile(r.dtstart, end)
 	if err != nil {
 		t.Error(err)
 	}
-	value := r.Includes(time.Date(1997, 9, 7, 9, 0, 0, 0, time.UTC))
+	value := r.Includes(time.Date(1997, 9, 6, 9, 0, 0, 0, time.UTC))
 	if value == false {
 		t.Errorf("get %v, want %v", value, want)
 	}
+	// the fifth instance is the last one
+	value = r.Includes(time.Date(1997, 9, 7, 9, 0, 0, 0, time.UTC))
+	if value == true {
+		t.Errorf("get %v, want %v", value, false)
+	}
 }
 
 // Test cases from Python Dateutil
@@ -3780,6 +3786,18 @@ func TestUntil(t *testing.T) {
 	}
 }
 
+func TestOccurrencesUntil(t *testing.T) {
+	r, _ := NewRRule(ROption{Freq: DAILY,
+		Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)})
+	value := r.OccurrencesUntil(time.Date(1997, 9, 4, 9, 0, 0, 0, time.UTC))
+	want := []time.Time{time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC),
+		time.Date(1997, 9, 3, 9, 0, 0, 0, time.UTC),
+		time.Date(1997, 9, 4, 9, 0, 0, 0, time.UTC)}
+	if !timesEqual(value, want) {
+		t.Errorf("get %v, want %v", value, want)
+	}
+}
+
 func TestMaxYear(t *testing.T) {
 	r, _ := NewRRule(ROption{Freq: YEARLY,
 		Count:      3,
@@ -3933,3 +3951,334 @@ func TestRuleChangeDTStartTimezoneRespected(t *testing.T) {
 		}
 	}
 }
+
+func TestDTStartLocationIsKept(t *testing.T) {
+	amsterdam, _ := time.LoadLocation("Europe/Amsterdam")
+	r, _ := NewRRule(ROption{Freq: DAILY,
+		Count:   3,
+		Dtstart: time.Date(2021, 3, 27, 9, 0, 0, 0, amsterdam)})
+	want := []time.Time{time.Date(2021, 3, 27, 9, 0, 0, 0, amsterdam),
+		time.Date(2021, 3, 28, 9, 0, 0, 0, amsterdam),
+		time.Date(2021, 3, 29, 9, 0, 0, 0, amsterdam)}
+	value := r.All()
+	if !timesEqual(value, want) {
+		t.Errorf("get %v, want %v", value, want)
+	}
+
+	next := r.Iterator()
+	if value, ok := next(); !ok || value != want[0] {
+		t.Errorf("get %v, want %v", value, want[0])
+	}
+}
+
+func TestCompileWeekly(t *testing.T) {
+	r, _ := StrToRRule("FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE,FR;DTSTART=20210705T090000Z")
+	start := r.dtstart
+	err := r.Compile(start, start.Add(15*time.Minute))
+	if err != nil {
+		t.Error(err)
+	}
+	want := r.Between(start, time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC), true)
+	if len(want) != 6 || !areDatesInclusive(r, want) {
+		t.Errorf("get %v, want all included", want)
+	}
+	for _, dt := range []time.Time{
+		time.Date(2021, 7, 6, 9, 0, 0, 0, time.UTC),
+		time.Date(2021, 7, 12, 9, 0, 0, 0, time.UTC),
+		time.Date(2021, 7, 19, 9, 30, 0, 0, time.UTC),
+		time.Date(2021, 6, 28, 9, 0, 0, 0, time.UTC),
+	} {
+		if r.Includes(dt) {
+			t.Errorf("get %v included, want excluded", dt)
+		}
+	}
+}
+
+func TestCompileSubDaily(t *testing.T) {
+	for _, str := range []string{
+		"FREQ=HOURLY;INTERVAL=3;COUNT=5;DTSTART=20210705T090000Z",
+		"FREQ=MINUTELY;INTERVAL=15;COUNT=5;DTSTART=20210705T090000Z",
+		"FREQ=SECONDLY;INTERVAL=20;COUNT=5;DTSTART=20210705T090000Z",
+	} {
+		r, _ := StrToRRule(str)
+		if err := r.Compile(r.dtstart, r.dtstart); err != nil {
+			t.Error(err)
+		}
+		all := r.All()
+		if !areDatesInclusive(r, all) {
+			t.Errorf("%s: get %v, want all included", str, all)
+		}
+		last := all[len(all)-1]
+		if next := last.Add(last.Sub(all[len(all)-2])); r.Includes(next) {
+			t.Errorf("%s: get %v included after COUNT, want excluded", str, next)
+		}
+		if r.Includes(all[0].Add(-time.Hour * 24)) {
+			t.Errorf("%s: get %v included before DTSTART, want excluded", str, all[0].Add(-time.Hour*24))
+		}
+	}
+}
+
+func TestCompileSpans(t *testing.T) {
+	tests := []struct {
+		rrule    string
+		duration time.Duration
+		included []time.Time
+		excluded []time.Time
+	}{
+		{"FREQ=DAILY;COUNT=3;DTSTART=20210705T220000Z", 4 * time.Hour,
+			[]time.Time{time.Date(2021, 7, 6, 1, 0, 0, 0, time.UTC), time.Date(2021, 7, 8, 1, 59, 0, 0, time.UTC)},
+			[]time.Time{time.Date(2021, 7, 5, 1, 0, 0, 0, time.UTC), time.Date(2021, 7, 6, 2, 0, 0, 0, time.UTC)}},
+		{"FREQ=WEEKLY;BYDAY=FR;DTSTART=20210702T220000Z", 4 * time.Hour,
+			[]time.Time{time.Date(2021, 7, 3, 1, 0, 0, 0, time.UTC), time.Date(2021, 7, 10, 0, 0, 0, 0, time.UTC)},
+			[]time.Time{time.Date(2021, 7, 4, 1, 0, 0, 0, time.UTC), time.Date(2021, 7, 3, 3, 0, 0, 0, time.UTC)}},
+		{"FREQ=YEARLY;BYMONTH=7;BYDAY=1FR;DTSTART=20210702T000000Z", 72 * time.Hour,
+			[]time.Time{time.Date(2021, 7, 2, 12, 0, 0, 0, time.UTC), time.Date(2021, 7, 4, 23, 0, 0, 0, time.UTC),
+				time.Date(2022, 7, 3, 12, 0, 0, 0, time.UTC)},
+			[]time.Time{time.Date(2021, 7, 5, 0, 0, 0, 0, time.UTC), time.Date(2022, 7, 6, 12, 0, 0, 0, time.UTC)}},
+		{"FREQ=YEARLY;DTSTART=20171222T000000Z", time.Date(2018, 3, 21, 0, 0, 0, 0, time.UTC).Sub(time.Date(2017, 12, 22, 0, 0, 0, 0, time.UTC)),
+			[]time.Time{time.Date(2017, 12, 31, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 15, 12, 0, 0, 0, time.UTC),
+				time.Date(2021, 3, 20, 12, 0, 0, 0, time.UTC)},
+			[]time.Time{time.Date(2017, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 3, 21, 0, 0, 0, 0, time.UTC),
+				time.Date(2019, 12, 21, 0, 0, 0, 0, time.UTC)}},
+	}
+	for _, test := range tests {
+		r, _ := StrToRRule(test.rrule)
+		if err := r.Compile(r.dtstart, r.dtstart.Add(test.duration)); err != nil {
+			t.Error(err)
+		}
+		for _, dt := range test.included {
+			if !r.Includes(dt) {
+				t.Errorf("%s: get %v excluded, want included", test.rrule, dt)
+			}
+		}
+		for _, dt := range test.excluded {
+			if r.Includes(dt) {
+				t.Errorf("%s: get %v included, want excluded", test.rrule, dt)
+			}
+		}
+	}
+}
+
+func TestDateRangeCrossingNewYear(t *testing.T) {
+	dr := DateRange(time.Date(2017, 12, 22, 0, 0, 0, 0, time.UTC), time.Date(2018, 3, 20, 0, 0, 0, 0, time.UTC))
+	for _, dt := range []time.Time{
+		time.Date(2018, 12, 22, 0, 0, 0, 0, time.UTC),
+		time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2019, 3, 20, 0, 0, 0, 0, time.UTC),
+	} {
+		if !dr.Includes(dt) {
+			t.Errorf("get %v excluded, want included", dt)
+		}
+	}
+	for _, dt := range []time.Time{
+		time.Date(2018, 12, 21, 0, 0, 0, 0, time.UTC),
+		time.Date(2019, 3, 21, 0, 0, 0, 0, time.UTC),
+		time.Date(2019, 7, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		if dr.Includes(dt) {
+			t.Errorf("get %v included, want excluded", dt)
+		}
+	}
+}
+
+// ruleCorpus holds rules of the test cases above, they are used with a DTSTART of
+// 19970902T090000 to compare the compiled rules and the serialized rules with the iterator
+var ruleCorpus = []string{
+	"FREQ=YEARLY;COUNT=3",
+	"FREQ=YEARLY;INTERVAL=2;COUNT=3",
+	"FREQ=YEARLY;COUNT=3;BYMONTH=1,3",
+	"FREQ=YEARLY;COUNT=3;BYMONTHDAY=1,3",
+	"FREQ=YEARLY;COUNT=3;BYMONTH=1,3;BYMONTHDAY=5,7",
+	"FREQ=YEARLY;COUNT=3;BYDAY=TU,TH",
+	"FREQ=YEARLY;COUNT=3;BYDAY=1TU,-1TH",
+	"FREQ=YEARLY;COUNT=3;BYDAY=3TU,-3TH",
+	"FREQ=YEARLY;COUNT=3;BYMONTH=1,3;BYDAY=1TU,-1TH",
+	"FREQ=YEARLY;COUNT=4;BYYEARDAY=1,100,200,365",
+	"FREQ=YEARLY;COUNT=4;BYYEARDAY=-365,-266,-166,-1",
+	"FREQ=YEARLY;COUNT=3;BYWEEKNO=20",
+	"FREQ=YEARLY;COUNT=3;BYWEEKNO=1;BYDAY=MO",
+	"FREQ=YEARLY;COUNT=3;BYWEEKNO=52;BYDAY=SU",
+	"FREQ=YEARLY;COUNT=3;BYWEEKNO=-1;BYDAY=SU",
+	"FREQ=YEARLY;COUNT=3;BYEASTER=0",
+	"FREQ=YEARLY;COUNT=3;BYEASTER=-2",
+	"FREQ=YEARLY;COUNT=3;BYMONTHDAY=13;BYDAY=FR",
+	"FREQ=YEARLY;COUNT=3;BYMONTHDAY=15;BYHOUR=6,18;BYSETPOS=3,-3",
+	"FREQ=MONTHLY;COUNT=3",
+	"FREQ=MONTHLY;COUNT=3;BYMONTHDAY=-1,-3",
+	"FREQ=MONTHLY;COUNT=6;BYDAY=1TU,-1TH",
+	"FREQ=MONTHLY;COUNT=3;BYDAY=2TU",
+	"FREQ=MONTHLY;COUNT=3;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-2",
+	"FREQ=MONTHLY;COUNT=3;BYMONTHDAY=13;BYDAY=FR",
+	"FREQ=MONTHLY;COUNT=5;BYMONTHDAY=31",
+	"FREQ=MONTHLY;INTERVAL=18;COUNT=10;BYMONTHDAY=10,11,12,13,14,15",
+	"FREQ=WEEKLY;COUNT=3",
+	"FREQ=WEEKLY;INTERVAL=2;COUNT=6;BYDAY=TU,TH",
+	"FREQ=WEEKLY;COUNT=3;BYMONTH=1,3",
+	"FREQ=WEEKLY;COUNT=4;WKST=SU;INTERVAL=2;BYDAY=TU,SU",
+	"FREQ=WEEKLY;COUNT=3;BYDAY=TU,TH;BYSETPOS=-1",
+	"FREQ=DAILY;COUNT=3",
+	"FREQ=DAILY;INTERVAL=92;COUNT=3",
+	"FREQ=DAILY;COUNT=3;BYMONTH=1,3",
+	"FREQ=DAILY;COUNT=3;BYDAY=TU,TH",
+	"FREQ=DAILY;COUNT=3;BYMONTHDAY=-1",
+	"FREQ=DAILY;COUNT=3;BYHOUR=6,18",
+	"FREQ=HOURLY;COUNT=3",
+	"FREQ=HOURLY;INTERVAL=2;COUNT=3",
+	"FREQ=HOURLY;COUNT=3;BYMONTH=1,3",
+	"FREQ=HOURLY;COUNT=3;BYHOUR=6,18",
+	"FREQ=HOURLY;COUNT=3;BYMINUTE=6,18",
+	"FREQ=HOURLY;COUNT=3;BYMINUTE=0,30;BYSETPOS=-1",
+	"FREQ=MINUTELY;COUNT=3",
+	"FREQ=MINUTELY;INTERVAL=1501;COUNT=3",
+	"FREQ=MINUTELY;COUNT=3;BYHOUR=6,18",
+	"FREQ=SECONDLY;COUNT=3",
+	"FREQ=SECONDLY;INTERVAL=90061;COUNT=3",
+}
+
+func TestCompileAgreesWithIterator(t *testing.T) {
+	for _, str := range ruleCorpus {
+		r, err := StrToRRule(str + ";DTSTART=19970902T090000Z")
+		if err != nil {
+			t.Errorf("%s: %s", str, err)
+			continue
+		}
+		all := r.All()
+		last := all[len(all)-1]
+		// the occurrences last an hour, or a second for the sub-daily rules so that they do not
+		// cover the next steps
+		end := r.dtstart.Add(time.Hour)
+		if r.freq >= HOURLY {
+			end = r.dtstart.Add(time.Second)
+		}
+		if err := r.Compile(r.dtstart, end); err != nil {
+			t.Errorf("%s: %s", str, err)
+			continue
+		}
+		for _, dt := range all {
+			if !r.Includes(dt) {
+				t.Errorf("%s: get %v excluded, want included", str, dt)
+			}
+		}
+
+		// every time between the occurrences at the resolution of the rule is excluded
+		step, slot := 24*time.Hour, 24*time.Hour
+		switch r.freq {
+		case HOURLY:
+			step, slot = time.Hour, time.Second
+		case MINUTELY:
+			step, slot = time.Minute, time.Second
+		case SECONDLY:
+			step, slot = time.Second, time.Second
+		}
+		occurs := map[time.Time]bool{}
+		for _, dt := range all {
+			occurs[dt.Truncate(slot)] = true
+		}
+		// and COUNT ends the rule after its last instance
+		horizon := last.AddDate(3, 0, 0)
+		switch r.freq {
+		case HOURLY:
+			horizon = last.Add(1000 * time.Hour)
+		case MINUTELY, SECONDLY:
+			horizon = last.AddDate(0, 0, 2)
+		}
+		for dt := r.dtstart; dt.Before(horizon); dt = dt.Add(step) {
+			if !occurs[dt.Truncate(slot)] && r.Includes(dt) {
+				t.Errorf("%s: get %v included, want excluded", str, dt)
+				break
+			}
+		}
+	}
+}
+
+func TestOccursOnAndAt(t *testing.T) {
+	for _, str := range []string{
+		"FREQ=YEARLY;BYMONTH=7;BYDAY=1MO",
+		"FREQ=MONTHLY;BYDAY=1MO",
+		"FREQ=WEEKLY;BYDAY=MO",
+		"FREQ=DAILY;INTERVAL=7",
+		"FREQ=HOURLY;INTERVAL=168",
+	} {
+		r, _ := StrToRRule(str + ";DTSTART=20210705T220000Z")
+		r.Duration(4 * time.Hour)
+		for _, day := range []time.Time{
+			time.Date(2021, 7, 5, 0, 0, 0, 0, time.UTC),
+			time.Date(2021, 7, 6, 0, 0, 0, 0, time.UTC),
+		} {
+			if !r.OccursOn(day) {
+				t.Errorf("%s: get %v excluded, want included", str, day)
+			}
+		}
+		if day := time.Date(2021, 7, 7, 0, 0, 0, 0, time.UTC); r.OccursOn(day) {
+			t.Errorf("%s: get %v included, want excluded", str, day)
+		}
+		if dt := time.Date(2021, 7, 6, 1, 59, 0, 0, time.UTC); !r.OccursAt(dt) {
+			t.Errorf("%s: get %v excluded, want included", str, dt)
+		}
+		for _, dt := range []time.Time{
+			time.Date(2021, 7, 5, 21, 59, 0, 0, time.UTC),
+			time.Date(2021, 7, 6, 2, 0, 0, 0, time.UTC),
+		} {
+			if r.OccursAt(dt) {
+				t.Errorf("%s: get %v included, want excluded", str, dt)
+			}
+		}
+		if start, ok := r.OccurrenceAt(time.Date(2021, 7, 6, 1, 0, 0, 0, time.UTC)); !ok || !start.Equal(r.dtstart) {
+			t.Errorf("%s: get %v, want %v", str, start, r.dtstart)
+		}
+	}
+}
+
+func TestSubDailyIncludesAgreesWithOccursAt(t *testing.T) {
+	for _, str := range []string{
+		"FREQ=HOURLY;INTERVAL=3;COUNT=10",
+		"FREQ=HOURLY;COUNT=10;BYMINUTE=0,40",
+		"FREQ=MINUTELY;INTERVAL=50;COUNT=10",
+	} {
+		for _, duration := range []time.Duration{0, 30 * time.Minute, 2 * time.Hour} {
+			r, _ := StrToRRule(str + ";DTSTART=20210705T090000Z")
+			if err := r.Compile(r.dtstart, r.dtstart.Add(duration)); err != nil {
+				t.Fatal(err)
+			}
+			for dt := r.dtstart.Add(-time.Hour); dt.Before(r.dtstart.AddDate(0, 0, 2)); dt = dt.Add(5 * time.Minute) {
+				if r.Includes(dt) != r.OccursAt(dt) {
+					t.Errorf("%s for %v: get %v for Includes(%v), want %v", str, duration, r.Includes(dt), dt, r.OccursAt(dt))
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestCompileUntil(t *testing.T) {
+	for _, str := range []string{
+		"FREQ=YEARLY;UNTIL=20000902T090000Z",
+		"FREQ=MONTHLY;UNTIL=19980102T090000Z",
+		"FREQ=MONTHLY;BYMONTHDAY=2;UNTIL=19980102",
+		"FREQ=WEEKLY;UNTIL=19970923T090000Z",
+		"FREQ=DAILY;UNTIL=19970905",
+		"FREQ=HOURLY;INTERVAL=6;UNTIL=19970903T090000Z",
+	} {
+		r, err := StrToRRule(str + ";DTSTART=19970902T090000Z")
+		if err != nil {
+			t.Fatalf("%s: %s", str, err)
+		}
+		all := r.All()
+		last := all[len(all)-1]
+		if y, m, d := last.Date(); y != r.until.Year() || m != r.until.Month() || d != r.until.Day() {
+			t.Errorf("%s: get last instance %v, want one on the day of UNTIL", str, last)
+		}
+		if err := r.Compile(r.dtstart, r.dtstart.Add(time.Hour)); err != nil {
+			t.Fatalf("%s: %s", str, err)
+		}
+		for _, dt := range all {
+			if !r.Includes(dt) {
+				t.Errorf("%s: get %v excluded, want included", str, dt)
+			}
+		}
+		rule, _ := StrToRRule(strings.Split(str, ";UNTIL")[0] + ";DTSTART=19970902T090000Z")
+		if next := rule.After(last, false); r.Includes(next) {
+			t.Errorf("%s: get %v included, want excluded", str, next)
+		}
+	}
+}
@@ -0,0 +1,405 @@
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Set allows more complex recurrence setups, mixing multiple rules, dates, exclusion rules, and exclusion dates
+type Set struct {
+	dtstart  time.Time
+	rrule    []*RRule
+	rdate    []time.Time
+	exrule   []*RRule
+	exdate   []time.Time
+	compiled TemporalExpression
+	duration time.Duration
+}
+
+// DTStart sets dtstart property for set, it is also applied to all the rules of the set
+func (set *Set) DTStart(dtstart time.Time) {
+	set.dtstart = dtstart.Truncate(time.Second)
+	for _, r := range set.rrule {
+		r.DTStart(set.dtstart)
+	}
+	for _, r := range set.exrule {
+		r.DTStart(set.dtstart)
+	}
+}
+
+// GetDTStart gets dtstart for set
+func (set *Set) GetDTStart() time.Time {
+	return set.dtstart
+}
+
+// RRule includes the given rrule instance in the recurrence set generation
+func (set *Set) RRule(rrule *RRule) {
+	if !set.dtstart.IsZero() {
+		rrule.DTStart(set.dtstart)
+	}
+	set.rrule = append(set.rrule, rrule)
+}
+
+// GetRRule returns the rrules in the set
+func (set *Set) GetRRule() []*RRule {
+	return set.rrule
+}
+
+// RDate includes the given datetime instance in the recurrence set generation
+func (set *Set) RDate(rdate time.Time) {
+	set.rdate = append(set.rdate, rdate)
+}
+
+// GetRDate returns the rdates in the set
+func (set *Set) GetRDate() []time.Time {
+	return set.rdate
+}
+
+// ExRule includes the given rrule instance in the recurrence set exclusion list, dates which
+// are part of the given recurrence rules will not be generated, even if some inclusive rrule
+// or rdate matches them
+func (set *Set) ExRule(exrule *RRule) {
+	if !set.dtstart.IsZero() {
+		exrule.DTStart(set.dtstart)
+	}
+	set.exrule = append(set.exrule, exrule)
+}
+
+// GetExRule returns the exrules in the set
+func (set *Set) GetExRule() []*RRule {
+	return set.exrule
+}
+
+// ExDate includes the given datetime instance in the recurrence set exclusion list, dates
+// included that way will not be generated, even if some inclusive rrule or rdate matches them
+func (set *Set) ExDate(exdate time.Time) {
+	set.exdate = append(set.exdate, exdate)
+}
+
+// GetExDate returns the exdates in the set
+func (set *Set) GetExDate() []time.Time {
+	return set.exdate
+}
+
+type genItem struct {
+	dt  time.Time
+	gen Next
+}
+
+type genItemSlice []genItem
+
+func (s genItemSlice) Len() int           { return len(s) }
+func (s genItemSlice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s genItemSlice) Less(i, j int) bool { return s[i].dt.Before(s[j].dt) }
+
+func addGenList(genList *[]genItem, next Next) {
+	dt, ok := next()
+	if ok {
+		*genList = append(*genList, genItem{dt, next})
+	}
+}
+
+// Iterator returns a generator of the occurrences of the set, the occurrences of the rules
+// and the dates are merged in order and the excluded ones are skipped
+func (set *Set) Iterator() Next {
+	rlist := []genItem{}
+	exlist := []genItem{}
+
+	rdates := append([]time.Time{}, set.rdate...)
+	sort.Sort(timeSlice(rdates))
+	addGenList(&rlist, timeSliceIterator(rdates))
+	for _, r := range set.rrule {
+		addGenList(&rlist, r.Iterator())
+	}
+	sort.Sort(genItemSlice(rlist))
+
+	exdates := append([]time.Time{}, set.exdate...)
+	sort.Sort(timeSlice(exdates))
+	addGenList(&exlist, timeSliceIterator(exdates))
+	for _, r := range set.exrule {
+		addGenList(&exlist, r.Iterator())
+	}
+	sort.Sort(genItemSlice(exlist))
+
+	lastdt := time.Time{}
+	return func() (time.Time, bool) {
+		for len(rlist) != 0 {
+			dt := rlist[0].dt
+			var ok bool
+			rlist[0].dt, ok = rlist[0].gen()
+			if !ok {
+				rlist = rlist[1:]
+			}
+			sort.Sort(genItemSlice(rlist))
+			if lastdt.IsZero() || !lastdt.Equal(dt) {
+				for len(exlist) != 0 && exlist[0].dt.Before(dt) {
+					exlist[0].dt, ok = exlist[0].gen()
+					if !ok {
+						exlist = exlist[1:]
+					}
+					sort.Sort(genItemSlice(exlist))
+				}
+				lastdt = dt
+				if len(exlist) == 0 || !dt.Equal(exlist[0].dt) {
+					return dt, true
+				}
+			}
+		}
+		return time.Time{}, false
+	}
+}
+
+// All returns all occurrences of the set
+func (set *Set) All() []time.Time {
+	return all(set.Iterator())
+}
+
+// Between returns all the occurrences of the set between after and before,
+// the inc keyword defines what happens if after and/or before are themselves occurrences
+func (set *Set) Between(after, before time.Time, inc bool) []time.Time {
+	return between(set.Iterator(), after, before, inc)
+}
+
+// Before returns the last occurrence before the given datetime instance, the inc keyword
+// defines what happens if dt is an occurrence
+func (set *Set) Before(dt time.Time, inc bool) time.Time {
+	return before(set.Iterator(), dt, inc)
+}
+
+// After returns the first occurrence after the given datetime instance, the inc keyword
+// defines what happens if dt is an occurrence
+func (set *Set) After(dt time.Time, inc bool) time.Time {
+	return after(set.Iterator(), dt, inc)
+}
+
+// Compile compiles the set into a temporal expression for occurrences that last from start
+// till end, a time is included when it is part of an occurrence of one of the rules or dates
+// and it is not part of an excluded occurrence
+func (set *Set) Compile(start time.Time, end time.Time) error {
+	set.duration = end.Sub(start)
+	include := []TemporalExpression{}
+	for _, r := range set.rrule {
+		if err := r.Compile(start, end); err != nil {
+			set.compiled = Never
+			return err
+		}
+		include = append(include, r)
+	}
+	for _, rdate := range set.rdate {
+		include = append(include, set.occurrence(rdate, end.Sub(start)))
+	}
+
+	exclude := []TemporalExpression{}
+	for _, r := range set.exrule {
+		if err := r.Compile(start, end); err != nil {
+			set.compiled = Never
+			return err
+		}
+		exclude = append(exclude, r)
+	}
+	for _, exdate := range set.exdate {
+		exclude = append(exclude, set.occurrence(exdate, end.Sub(start)))
+	}
+
+	set.compiled = And(Or(include...), Not(Or(exclude...)))
+	return nil
+}
+
+// occurrence returns a temporal expression that matches a single occurrence at dt in the
+// same way as the rules of the set match their occurrences, that is the time window of the
+// occurrence
+func (set *Set) occurrence(dt time.Time, duration time.Duration) TemporalExpression {
+	return Span(Dates(dt), duration, dt)
+}
+
+// Includes will determine if 'dt' is regarded by the compiled set as included, the days and
+// times of the set are those of the location of its DTSTART
+func (set *Set) Includes(dt time.Time) bool {
+	if set.compiled == nil {
+		return false
+	}
+	if !set.dtstart.IsZero() {
+		dt = dt.In(set.dtstart.Location())
+	}
+	return set.compiled.Includes(dt)
+}
+
+// Duration sets the duration of the occurrences that is used by OccursOn and OccursAt,
+// Compile sets it to the time from start till end
+func (set *Set) Duration(duration time.Duration) {
+	set.duration = duration
+}
+
+// OccursOn returns true when an occurrence takes place (partly) on the day of 'day' in its location
+func (set *Set) OccursOn(day time.Time) bool {
+	_, ok := occurrenceOn(set.Iterator(), day, set.duration)
+	return ok
+}
+
+// OccurrenceOn returns the start of the first occurrence that takes place on the day of 'day'
+func (set *Set) OccurrenceOn(day time.Time) (time.Time, bool) {
+	return occurrenceOn(set.Iterator(), day, set.duration)
+}
+
+// OccursAt returns true when an occurrence is in progress at the instant 'dt'
+func (set *Set) OccursAt(dt time.Time) bool {
+	_, ok := occurrenceAt(set.Iterator(), dt, set.duration)
+	return ok
+}
+
+// OccurrenceAt returns the start of the occurrence that is in progress at the instant 'dt'
+func (set *Set) OccurrenceAt(dt time.Time) (time.Time, bool) {
+	return occurrenceAt(set.Iterator(), dt, set.duration)
+}
+
+// Recurrence returns the DTSTART, RRULE, RDATE, EXRULE and EXDATE lines of the set, with a
+// TZID for the times that are not in UTC, they are parsed again by StrSliceToRRuleSet
+func (set *Set) Recurrence() []string {
+	lines := []string{}
+	if !set.dtstart.IsZero() {
+		lines = append(lines, "DTSTART"+timeToDtStartStr(set.dtstart))
+	}
+	for _, r := range set.rrule {
+		lines = append(lines, "RRULE:"+set.ruleString(r))
+	}
+	for _, rdate := range set.rdate {
+		lines = append(lines, "RDATE"+timeToDtStartStr(rdate))
+	}
+	for _, r := range set.exrule {
+		lines = append(lines, "EXRULE:"+set.ruleString(r))
+	}
+	for _, exdate := range set.exdate {
+		lines = append(lines, "EXDATE"+timeToDtStartStr(exdate))
+	}
+	return lines
+}
+
+// ruleString returns the value of a RRULE or EXRULE line, without a DTSTART line for the set
+// the rule keeps its own DTSTART (in UTC)
+func (set *Set) ruleString(r *RRule) string {
+	if set.dtstart.IsZero() {
+		option := r.options()
+		option.RFC = false
+		return option.String()
+	}
+	return r.RRuleString()
+}
+
+// String returns the lines of Recurrence separated by newlines
+func (set *Set) String() string {
+	return strings.Join(set.Recurrence(), "\n")
+}
+
+// StrToRRuleSet converts string to RRuleSet
+func StrToRRuleSet(s string) (*Set, error) {
+	return StrToRRuleSetInLoc(s, time.UTC)
+}
+
+// StrToRRuleSetInLoc is same as StrToRRuleSet, but it uses the default location for
+// the DTSTART, RDATE and EXDATE values without a TZID
+func StrToRRuleSetInLoc(s string, defaultLoc *time.Location) (*Set, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, errors.New("empty string")
+	}
+	return StrSliceToRRuleSetInLoc(strings.Split(s, "\n"), defaultLoc)
+}
+
+// StrSliceToRRuleSet converts given str slice to RRuleSet
+func StrSliceToRRuleSet(ss []string) (*Set, error) {
+	return StrSliceToRRuleSetInLoc(ss, time.UTC)
+}
+
+// StrSliceToRRuleSetInLoc is same as StrSliceToRRuleSet, but it uses the default location
+// for the DTSTART, RDATE and EXDATE values without a TZID
+func StrSliceToRRuleSetInLoc(ss []string, defaultLoc *time.Location) (*Set, error) {
+	return StrSliceToRRuleSetInLocations(ss, defaultLoc, nil)
+}
+
+// StrToRRuleSetInLocations is same as StrToRRuleSetInLoc, but it resolves the TZID
+// parameters with the given locations, for example those of a calendar, before the
+// IANA database
+func StrToRRuleSetInLocations(s string, defaultLoc *time.Location, locations Locations) (*Set, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, errors.New("empty string")
+	}
+	return StrSliceToRRuleSetInLocations(strings.Split(s, "\n"), defaultLoc, locations)
+}
+
+// StrSliceToRRuleSetInLocations is same as StrSliceToRRuleSetInLoc, but it resolves the
+// TZID parameters with the given locations before the IANA database
+func StrSliceToRRuleSetInLocations(ss []string, defaultLoc *time.Location, locations Locations) (*Set, error) {
+	if len(ss) == 0 {
+		return &Set{}, nil
+	}
+
+	set := Set{}
+
+	// According to RFC DTSTART is always the first line.
+	firstName, err := processRRuleName(ss[0])
+	if err != nil {
+		return nil, err
+	}
+	if firstName == "DTSTART" {
+		dt, err := strToDtStart(strings.TrimSpace(ss[0])[len(firstName)+1:], defaultLoc, locations)
+		if err != nil {
+			return nil, fmt.Errorf("strToDtStart failed: %v", err)
+		}
+		set.DTStart(dt)
+		ss = ss[1:]
+	}
+
+	for _, line := range ss {
+		line = strings.TrimSpace(line)
+		name, err := processRRuleName(line)
+		if err != nil {
+			return nil, err
+		}
+		rule := line[len(name)+1:]
+
+		switch name {
+		case "RRULE", "EXRULE":
+			// an UNTIL in local time is in the time zone of DTSTART
+			loc := defaultLoc
+			if !set.dtstart.IsZero() {
+				loc = set.dtstart.Location()
+			}
+			option, err := StrToROptionInLocation(rule, loc)
+			if err != nil {
+				return nil, fmt.Errorf("StrToROption failed: %v", err)
+			}
+			if !set.dtstart.IsZero() {
+				option.Dtstart = set.dtstart
+			}
+			r, err := NewRRule(*option)
+			if err != nil {
+				return nil, fmt.Errorf("NewRRule failed: %v", err)
+			}
+			if name == "RRULE" {
+				set.RRule(r)
+			} else {
+				set.ExRule(r)
+			}
+		case "RDATE", "EXDATE":
+			ts, err := StrToDatesInLocations(rule, defaultLoc, locations)
+			if err != nil {
+				return nil, fmt.Errorf("StrToDatesInLocations failed: %v", err)
+			}
+			for _, t := range ts {
+				if name == "RDATE" {
+					set.RDate(t)
+				} else {
+					set.ExDate(t)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("unsupported property: %v", name)
+		}
+	}
+
+	return &set, nil
+}
@@ -0,0 +1,176 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSet(t *testing.T) {
+	set := Set{}
+	r, _ := NewRRule(ROption{Freq: YEARLY, Count: 2, Byweekday: []RWeekday{TU},
+		Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)})
+	set.RRule(r)
+	set.RDate(time.Date(1997, 9, 4, 9, 0, 0, 0, time.UTC))
+	set.RDate(time.Date(1997, 9, 9, 9, 0, 0, 0, time.UTC))
+	value := set.All()
+	want := []time.Time{time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC),
+		time.Date(1997, 9, 4, 9, 0, 0, 0, time.UTC),
+		time.Date(1997, 9, 9, 9, 0, 0, 0, time.UTC)}
+	if !timesEqual(value, want) {
+		t.Errorf("get %v, want %v", value, want)
+	}
+}
+
+func TestSetExDate(t *testing.T) {
+	set := Set{}
+	r, _ := NewRRule(ROption{Freq: WEEKLY, Count: 4,
+		Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)})
+	set.RRule(r)
+	set.RDate(time.Date(1997, 9, 7, 9, 0, 0, 0, time.UTC))
+	set.ExDate(time.Date(1997, 9, 16, 9, 0, 0, 0, time.UTC))
+	value := set.All()
+	want := []time.Time{time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC),
+		time.Date(1997, 9, 7, 9, 0, 0, 0, time.UTC),
+		time.Date(1997, 9, 9, 9, 0, 0, 0, time.UTC),
+		time.Date(1997, 9, 23, 9, 0, 0, 0, time.UTC)}
+	if !timesEqual(value, want) {
+		t.Errorf("get %v, want %v", value, want)
+	}
+}
+
+func TestSetExRule(t *testing.T) {
+	set := Set{}
+	r, _ := NewRRule(ROption{Freq: DAILY, Count: 7,
+		Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)})
+	set.RRule(r)
+	r, _ = NewRRule(ROption{Freq: YEARLY, Byweekday: []RWeekday{SA, SU},
+		Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)})
+	set.ExRule(r)
+	value := set.All()
+	want := []time.Time{time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC),
+		time.Date(1997, 9, 3, 9, 0, 0, 0, time.UTC),
+		time.Date(1997, 9, 4, 9, 0, 0, 0, time.UTC),
+		time.Date(1997, 9, 5, 9, 0, 0, 0, time.UTC),
+		time.Date(1997, 9, 8, 9, 0, 0, 0, time.UTC)}
+	if !timesEqual(value, want) {
+		t.Errorf("get %v, want %v", value, want)
+	}
+	if value := set.After(time.Date(1997, 9, 5, 9, 0, 0, 0, time.UTC), false); value != want[4] {
+		t.Errorf("get %v, want %v", value, want[4])
+	}
+}
+
+func TestStrToRRuleSet(t *testing.T) {
+	set, err := StrToRRuleSet(`DTSTART;TZID=Europe/Amsterdam:20210705T090000
+RRULE:FREQ=WEEKLY;COUNT=4
+EXDATE;TZID=Europe/Amsterdam:20210712T090000
+RDATE:20210716T070000Z`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	amsterdam, _ := time.LoadLocation("Europe/Amsterdam")
+	value := set.All()
+	want := []time.Time{time.Date(2021, 7, 5, 9, 0, 0, 0, amsterdam),
+		time.Date(2021, 7, 16, 9, 0, 0, 0, amsterdam),
+		time.Date(2021, 7, 19, 9, 0, 0, 0, amsterdam),
+		time.Date(2021, 7, 26, 9, 0, 0, 0, amsterdam)}
+	if len(value) != len(want) {
+		t.Fatalf("get %v, want %v", value, want)
+	}
+	for i := range value {
+		if !value[i].Equal(want[i]) {
+			t.Errorf("get %v, want %v", value[i], want[i])
+		}
+	}
+
+	for _, str := range []string{"", "RRULE:FREQ=WEEKLY\nFOO:BAR", "EXDATE:2021"} {
+		if _, err := StrToRRuleSet(str); err == nil {
+			t.Errorf("StrToRRuleSet(%q) get no error, want error", str)
+		}
+	}
+}
+
+func TestSetCompile(t *testing.T) {
+	set, _ := StrToRRuleSet(`DTSTART:20210705T090000Z
+RRULE:FREQ=WEEKLY;BYDAY=MO
+EXDATE:20210712T090000Z
+RDATE:20210714T090000Z`)
+	start := set.GetDTStart()
+	if err := set.Compile(start, start.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	for _, dt := range []time.Time{
+		time.Date(2021, 7, 5, 9, 30, 0, 0, time.UTC),
+		time.Date(2021, 7, 14, 9, 30, 0, 0, time.UTC),
+		time.Date(2021, 7, 19, 9, 30, 0, 0, time.UTC),
+	} {
+		if !set.Includes(dt) {
+			t.Errorf("get %v excluded, want included", dt)
+		}
+	}
+	for _, dt := range []time.Time{
+		time.Date(2021, 7, 12, 9, 30, 0, 0, time.UTC),
+		time.Date(2021, 7, 14, 10, 30, 0, 0, time.UTC),
+	} {
+		if set.Includes(dt) {
+			t.Errorf("get %v included, want excluded", dt)
+		}
+	}
+}
+
+func TestSetRoundTrip(t *testing.T) {
+	for _, str := range []string{
+		`DTSTART;TZID=Europe/Amsterdam:20210705T090000
+RRULE:FREQ=WEEKLY;COUNT=10;BYDAY=MO,WE
+RDATE;TZID=Europe/Amsterdam:20210710T110000
+RDATE:20210711T090000Z
+EXRULE:FREQ=MONTHLY;COUNT=2;BYDAY=+1MO
+EXDATE;TZID=Europe/Amsterdam:20210714T090000`,
+		`DTSTART:19970902T090000Z
+RRULE:FREQ=YEARLY;COUNT=6;BYDAY=TU,TH
+RRULE:FREQ=YEARLY;COUNT=3;BYEASTER=0`,
+	} {
+		set, err := StrToRRuleSet(str)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value := set.String(); value != str {
+			t.Errorf("get %s, want %s", value, str)
+		}
+		value, err := StrToRRuleSet(set.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !timesEqual(value.All(), set.All()) {
+			t.Errorf("get %v, want %v", value.All(), set.All())
+		}
+	}
+
+	set := Set{}
+	r, _ := NewRRule(ROption{Freq: DAILY, Count: 3, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)})
+	set.RRule(r)
+	set.ExDate(time.Date(1997, 9, 3, 9, 0, 0, 0, time.UTC))
+	value, err := StrToRRuleSet(set.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !timesEqual(value.All(), set.All()) {
+		t.Errorf("get %v, want %v", value.All(), set.All())
+	}
+}
+
+func TestSetLocalUntil(t *testing.T) {
+	set, err := StrToRRuleSet(`DTSTART;TZID=Europe/Amsterdam:20210705T090000
+RRULE:FREQ=DAILY;UNTIL=20210707T080000`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	amsterdam, _ := time.LoadLocation("Europe/Amsterdam")
+	want := []time.Time{
+		time.Date(2021, 7, 5, 9, 0, 0, 0, amsterdam),
+		time.Date(2021, 7, 6, 9, 0, 0, 0, amsterdam),
+	}
+	if value := set.All(); !timesEqual(value, want) {
+		t.Errorf("get %v, want %v", value, want)
+	}
+}
@@ -3,6 +3,7 @@ package rrule
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
@@ -21,11 +22,19 @@ func timeToStr(time time.Time) string {
 	return time.UTC().Format(DateTimeFormat)
 }
 
-func timeToDtStartStr(time time.Time) string {
-	if time.Location().String() != "UTC" {
-		return fmt.Sprintf(";TZID=%s:%s", time.Location().String(), time.Format(LocalDateTimeFormat))
+// timeToDtStartStr returns the parameters and value of a DTSTART, RDATE or EXDATE, a TZID is
+// only written for a location that is loaded again by its name, other times such as those in
+// time.Local or a time.FixedZone are written in UTC
+func timeToDtStartStr(t time.Time) string {
+	if name := t.Location().String(); name != "UTC" && name != "Local" {
+		if loc, err := LoadLocation(name); err == nil {
+			_, offset := t.Zone()
+			if _, loaded := t.In(loc).Zone(); loaded == offset {
+				return fmt.Sprintf(";TZID=%s:%s", name, t.Format(LocalDateTimeFormat))
+			}
+		}
 	}
-	return fmt.Sprintf(":%s", time.Format(DateTimeFormat))
+	return fmt.Sprintf(":%s", t.UTC().Format(DateTimeFormat))
 }
 
 func strToTimeInLoc(str string, loc *time.Location) (time.Time, error) {
@@ -137,7 +146,9 @@ func (option *ROption) String() string {
 	if option.Count != 0 {
 		result = append(result, fmt.Sprintf("COUNT=%v", option.Count))
 	}
-	if !option.Until.IsZero() {
+	if option.UntilDate {
+		result = append(result, fmt.Sprintf("UNTIL=%v", option.Until.Format(DateFormat)))
+	} else if !option.Until.IsZero() {
 		result = append(result, fmt.Sprintf("UNTIL=%v", timeToStr(option.Until)))
 	}
 	result = appendIntsOption(result, "BYSETPOS", option.Bysetpos)
@@ -201,6 +212,11 @@ func StrToROptionInLocation(rfcString string, loc *time.Location) (*ROption, err
 			result.Count, e = strconv.Atoi(value)
 		case "UNTIL":
 			result.Until, e = strToTimeInLoc(value, loc)
+			if len(value) == len(DateFormat) {
+				// an UNTIL date includes the instances on that day
+				result.Until = result.Until.AddDate(0, 0, 1).Add(-time.Second)
+				result.UntilDate = true
+			}
 		case "BYSETPOS":
 			result.Bysetpos, e = strToInts(value)
 		case "BYMONTH":
@@ -239,10 +255,52 @@ func StrToROptionInLocation(rfcString string, loc *time.Location) (*ROption, err
 	return &result, nil
 }
 
+// RRuleString returns the value of the RRULE property for the options, that is without
+// DTSTART as required by RFC 5545
+func (option *ROption) RRuleString() string {
+	rfc := *option
+	rfc.RFC = true
+	return rfc.String()
+}
+
 func (r *RRule) String() string {
 	return r.OrigOptions.String()
 }
 
+// RRuleString returns the value of the RRULE property for the rule in its current state,
+// also after DTStart or Until changed it
+func (r *RRule) RRuleString() string {
+	option := r.options()
+	return option.RRuleString()
+}
+
+// Recurrence returns the DTSTART and RRULE lines of the rule, with a TZID for a DTSTART
+// that is not in UTC, they are parsed again by StrSliceToRRuleSet
+func (r *RRule) Recurrence() []string {
+	return []string{"DTSTART" + timeToDtStartStr(r.dtstart), "RRULE:" + r.RRuleString()}
+}
+
+// options returns the options that reproduce the rule in its current state, the BY* parts
+// that were derived from a DTSTART that has changed since are given explicitly
+func (r *RRule) options() ROption {
+	option := r.OrigOptions
+	option.Dtstart = r.dtstart
+	derived, err := NewRRule(option)
+	if err != nil {
+		return option
+	}
+	if !reflect.DeepEqual(derived.Options.Bymonth, r.Options.Bymonth) {
+		option.Bymonth = r.Options.Bymonth
+	}
+	if !reflect.DeepEqual(derived.Options.Bymonthday, r.Options.Bymonthday) {
+		option.Bymonthday = r.Options.Bymonthday
+	}
+	if !reflect.DeepEqual(derived.Options.Byweekday, r.Options.Byweekday) {
+		option.Byweekday = r.Options.Byweekday
+	}
+	return option
+}
+
 // StrToRRule converts string to RRule
 func StrToRRule(rfcString string) (*RRule, error) {
 	option, e := StrToROption(rfcString)
@@ -264,6 +322,12 @@ func StrToDates(str string) (ts []time.Time, err error) {
 // StrToDatesInLoc same as StrToDates but it consideres default location to parse dates in
 // in case no location specified with TZID parameter
 func StrToDatesInLoc(str string, defaultLoc *time.Location) (ts []time.Time, err error) {
+	return StrToDatesInLocations(str, defaultLoc, nil)
+}
+
+// StrToDatesInLocations same as StrToDatesInLoc but it resolves the TZID parameter
+// with the given locations before the IANA database
+func StrToDatesInLocations(str string, defaultLoc *time.Location, locations Locations) (ts []time.Time, err error) {
 	tmp := strings.Split(str, ":")
 	if len(tmp) > 2 {
 		return nil, fmt.Errorf("bad format")
@@ -273,7 +337,7 @@ func StrToDatesInLoc(str string, defaultLoc *time.Location) (ts []time.Time, err
 		params := strings.Split(tmp[0], ";")
 		for _, param := range params {
 			if strings.HasPrefix(param, "TZID=") {
-				loc, err = parseTZID(param)
+				loc, err = parseTZID(param, locations)
 			} else if param != "VALUE=DATE-TIME" && param != "VALUE=DATE" {
 				err = fmt.Errorf("unsupported: %v", param)
 			}
@@ -315,7 +379,7 @@ func processRRuleName(line string) (string, error) {
 
 // strToDtStart accepts string with format: "(TZID={timezone}:)?{time}" and parses it to a date
 // may be used to parse DTSTART rules, without the DTSTART; part.
-func strToDtStart(str string, defaultLoc *time.Location) (time.Time, error) {
+func strToDtStart(str string, defaultLoc *time.Location, locations Locations) (time.Time, error) {
 	tmp := strings.Split(str, ":")
 	if len(tmp) > 2 || len(tmp) == 0 {
 		return time.Time{}, fmt.Errorf("bad format")
@@ -323,7 +387,7 @@ func strToDtStart(str string, defaultLoc *time.Location) (time.Time, error) {
 
 	if len(tmp) == 2 {
 		// tzid
-		loc, err := parseTZID(tmp[0])
+		loc, err := parseTZID(tmp[0], locations)
 		if err != nil {
 			return time.Time{}, err
 		}
@@ -333,9 +397,9 @@ func strToDtStart(str string, defaultLoc *time.Location) (time.Time, error) {
 	return strToTimeInLoc(tmp[0], defaultLoc)
 }
 
-func parseTZID(s string) (*time.Location, error) {
+func parseTZID(s string, locations Locations) (*time.Location, error) {
 	if !strings.HasPrefix(s, "TZID=") || len(s) == len("TZID=") {
 		return nil, fmt.Errorf("bad TZID parameter format")
 	}
-	return time.LoadLocation(s[len("TZID="):])
+	return locations.Load(s[len("TZID="):])
 }
@@ -81,13 +81,13 @@ func TestStrToDtStart(t *testing.T) {
 	}
 
 	for _, item := range validCases {
-		if _, e := strToDtStart(item, time.UTC); e != nil {
+		if _, e := strToDtStart(item, time.UTC, nil); e != nil {
 			t.Errorf("strToDtStart(%q) error = %s, want nil", item, e.Error())
 		}
 	}
 
 	for _, item := range invalidCases {
-		if _, e := strToDtStart(item, time.UTC); e == nil {
+		if _, e := strToDtStart(item, time.UTC, nil); e == nil {
 			t.Errorf("strToDtStart(%q) err = nil, want not nil", item)
 		}
 	}
@@ -184,3 +184,361 @@ func TestProcessRRuleName(t *testing.T) {
 		}
 	}
 }
+
+// roundTripOptions holds the options of the rules of the test cases in rrule_test.go
+var roundTripOptions = []ROption{
+	{Freq: MONTHLY},
+	{Freq: MONTHLY, Count: 3, Bymonthday: []int{-1}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Interval: 15, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Bysetpos: []int{1, -1, 2}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC), Until: time.Date(1997, 9, 2, 11, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 5, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Interval: 2, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Interval: 100, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Bymonth: []int{1, 3}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Bymonthday: []int{1, 3}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Bymonth: []int{1, 3}, Bymonthday: []int{5, 7}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Byweekday: []RWeekday{TU, TH}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Byweekday: []RWeekday{TU.Nth(1), TH.Nth(-1)}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Byweekday: []RWeekday{TU.Nth(3), TH.Nth(-3)}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Bymonth: []int{1, 3}, Byweekday: []RWeekday{TU, TH}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Bymonth: []int{1, 3}, Byweekday: []RWeekday{TU.Nth(1), TH.Nth(-1)}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Bymonth: []int{1, 3}, Byweekday: []RWeekday{TU.Nth(3), TH.Nth(-3)}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Bymonthday: []int{1, 3}, Byweekday: []RWeekday{TU, TH}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Bymonth: []int{1, 3}, Bymonthday: []int{1, 3}, Byweekday: []RWeekday{TU, TH}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 4, Byyearday: []int{1, 100, 200, 365}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 4, Byyearday: []int{-365, -266, -166, -1}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 4, Bymonth: []int{4, 7}, Byyearday: []int{1, 100, 200, 365}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 4, Bymonth: []int{4, 7}, Byyearday: []int{-365, -266, -166, -1}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Byweekno: []int{20}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Byweekno: []int{1}, Byweekday: []RWeekday{MO}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Byweekno: []int{52}, Byweekday: []RWeekday{SU}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Byweekno: []int{-1}, Byweekday: []RWeekday{SU}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Byeaster: []int{0}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Byeaster: []int{1}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Byeaster: []int{-1}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Byweekno: []int{53}, Byweekday: []RWeekday{MO}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Byhour: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Byminute: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Bysecond: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Byhour: []int{6, 18}, Byminute: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Byhour: []int{6, 18}, Bysecond: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Byminute: []int{6, 18}, Bysecond: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Byhour: []int{6, 18}, Byminute: []int{6, 18}, Bysecond: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Bymonthday: []int{15}, Byhour: []int{6, 18}, Bysetpos: []int{3, -3}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 3, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 3, Interval: 2, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 3, Interval: 18, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 3, Bymonth: []int{1, 3}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 3, Bymonthday: []int{1, 3}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 3, Bymonth: []int{1, 3}, Bymonthday: []int{5, 7}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 3, Byweekday: []RWeekday{TU, TH}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 3, Byweekday: []RWeekday{TU.Nth(1), TH.Nth(-1)}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 3, Byweekday: []RWeekday{TU.Nth(3), TH.Nth(-3)}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 3, Bymonth: []int{1, 3}, Byweekday: []RWeekday{TU, TH}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 3, Bymonth: []int{1, 3}, Byweekday: []RWeekday{TU.Nth(1), TH.Nth(-1)}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 3, Bymonth: []int{1, 3}, Byweekday: []RWeekday{TU.Nth(3), TH.Nth(-3)}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 3, Bymonthday: []int{1, 3}, Byweekday: []RWeekday{TU, TH}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 3, Bymonth: []int{1, 3}, Bymonthday: []int{1, 3}, Byweekday: []RWeekday{TU, TH}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 4, Byyearday: []int{1, 100, 200, 365}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 4, Byyearday: []int{-365, -266, -166, -1}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 4, Bymonth: []int{4, 7}, Byyearday: []int{1, 100, 200, 365}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 4, Bymonth: []int{4, 7}, Byyearday: []int{-365, -266, -166, -1}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 3, Byweekno: []int{20}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 3, Byweekno: []int{1}, Byweekday: []RWeekday{MO}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 3, Byweekno: []int{52}, Byweekday: []RWeekday{SU}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 3, Byweekno: []int{-1}, Byweekday: []RWeekday{SU}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 3, Byweekno: []int{53}, Byweekday: []RWeekday{MO}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 3, Byeaster: []int{0}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 3, Byeaster: []int{1}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 3, Byeaster: []int{-1}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 3, Byhour: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 3, Byminute: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 3, Bysecond: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 3, Byhour: []int{6, 18}, Byminute: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 3, Byhour: []int{6, 18}, Bysecond: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 3, Byminute: []int{6, 18}, Bysecond: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 3, Byhour: []int{6, 18}, Byminute: []int{6, 18}, Bysecond: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Count: 3, Bymonthday: []int{13, 17}, Byhour: []int{6, 18}, Bysetpos: []int{3, -3}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 3, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 3, Interval: 2, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 3, Interval: 20, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 3, Bymonth: []int{1, 3}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 3, Bymonthday: []int{1, 3}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 3, Bymonth: []int{1, 3}, Bymonthday: []int{5, 7}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 3, Byweekday: []RWeekday{TU, TH}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 3, Byweekday: []RWeekday{TU.Nth(1), TH.Nth(-1)}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 3, Bymonth: []int{1, 3}, Byweekday: []RWeekday{TU, TH}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 3, Bymonth: []int{1, 3}, Byweekday: []RWeekday{TU.Nth(1), TH.Nth(-1)}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 3, Bymonthday: []int{1, 3}, Byweekday: []RWeekday{TU, TH}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 3, Bymonth: []int{1, 3}, Bymonthday: []int{1, 3}, Byweekday: []RWeekday{TU, TH}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 4, Byyearday: []int{1, 100, 200, 365}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 4, Byyearday: []int{-365, -266, -166, -1}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 4, Bymonth: []int{1, 7}, Byyearday: []int{1, 100, 200, 365}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 4, Bymonth: []int{1, 7}, Byyearday: []int{-365, -266, -166, -1}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 3, Byweekno: []int{20}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 3, Byweekno: []int{1}, Byweekday: []RWeekday{MO}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 3, Byweekno: []int{52}, Byweekday: []RWeekday{SU}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 3, Byweekno: []int{-1}, Byweekday: []RWeekday{SU}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 3, Byweekno: []int{53}, Byweekday: []RWeekday{MO}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 3, Byeaster: []int{0}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 3, Byeaster: []int{1}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 3, Byeaster: []int{-1}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 3, Byhour: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 3, Byminute: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 3, Bysecond: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 3, Byhour: []int{6, 18}, Byminute: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 3, Byhour: []int{6, 18}, Bysecond: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 3, Byminute: []int{6, 18}, Bysecond: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 3, Byhour: []int{6, 18}, Byminute: []int{6, 18}, Bysecond: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 3, Byweekday: []RWeekday{TU, TH}, Byhour: []int{6, 18}, Bysetpos: []int{3, -3}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Interval: 2, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Interval: 92, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Bymonth: []int{1, 3}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Bymonthday: []int{1, 3}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Bymonth: []int{1, 3}, Bymonthday: []int{5, 7}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Byweekday: []RWeekday{TU, TH}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Byweekday: []RWeekday{TU.Nth(1), TH.Nth(-1)}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Bymonth: []int{1, 3}, Byweekday: []RWeekday{TU, TH}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Bymonth: []int{1, 3}, Byweekday: []RWeekday{TU.Nth(1), TH.Nth(-1)}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Bymonthday: []int{1, 3}, Byweekday: []RWeekday{TU, TH}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Bymonth: []int{1, 3}, Bymonthday: []int{1, 3}, Byweekday: []RWeekday{TU, TH}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 4, Byyearday: []int{1, 100, 200, 365}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 4, Byyearday: []int{-365, -266, -166, -1}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 4, Bymonth: []int{1, 7}, Byyearday: []int{1, 100, 200, 365}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 4, Bymonth: []int{1, 7}, Byyearday: []int{-365, -266, -166, -1}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Byweekno: []int{20}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Byweekno: []int{1}, Byweekday: []RWeekday{MO}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Byweekno: []int{52}, Byweekday: []RWeekday{SU}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Byweekno: []int{-1}, Byweekday: []RWeekday{SU}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Byweekno: []int{53}, Byweekday: []RWeekday{MO}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Byeaster: []int{0}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Byeaster: []int{1}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Byeaster: []int{-1}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Byhour: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Byminute: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Bysecond: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Byhour: []int{6, 18}, Byminute: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Byhour: []int{6, 18}, Bysecond: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Byminute: []int{6, 18}, Bysecond: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Byhour: []int{6, 18}, Byminute: []int{6, 18}, Bysecond: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Byhour: []int{6, 18}, Byminute: []int{15, 45}, Bysetpos: []int{3, -3}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 3, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 3, Interval: 2, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 3, Interval: 769, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 3, Bymonth: []int{1, 3}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 3, Bymonthday: []int{1, 3}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 3, Bymonth: []int{1, 3}, Bymonthday: []int{5, 7}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 3, Byweekday: []RWeekday{TU, TH}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 3, Byweekday: []RWeekday{TU.Nth(1), TH.Nth(-1)}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 3, Bymonth: []int{1, 3}, Byweekday: []RWeekday{TU, TH}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 3, Bymonth: []int{1, 3}, Byweekday: []RWeekday{TU.Nth(1), TH.Nth(-1)}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 3, Bymonthday: []int{1, 3}, Byweekday: []RWeekday{TU, TH}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 3, Bymonth: []int{1, 3}, Bymonthday: []int{1, 3}, Byweekday: []RWeekday{TU, TH}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 4, Byyearday: []int{1, 100, 200, 365}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 4, Byyearday: []int{-365, -266, -166, -1}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 4, Bymonth: []int{4, 7}, Byyearday: []int{1, 100, 200, 365}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 4, Bymonth: []int{4, 7}, Byyearday: []int{-365, -266, -166, -1}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 3, Byweekno: []int{20}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 3, Byweekno: []int{1}, Byweekday: []RWeekday{MO}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 3, Byweekno: []int{52}, Byweekday: []RWeekday{SU}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 3, Byweekno: []int{-1}, Byweekday: []RWeekday{SU}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 3, Byweekno: []int{53}, Byweekday: []RWeekday{MO}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 3, Byeaster: []int{0}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 3, Byeaster: []int{1}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 3, Byeaster: []int{-1}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 3, Byhour: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 3, Byminute: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 3, Bysecond: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 3, Byhour: []int{6, 18}, Byminute: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 3, Byhour: []int{6, 18}, Bysecond: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 3, Byminute: []int{6, 18}, Bysecond: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 3, Byhour: []int{6, 18}, Byminute: []int{6, 18}, Bysecond: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: HOURLY, Count: 3, Byminute: []int{15, 45}, Bysecond: []int{15, 45}, Bysetpos: []int{3, -3}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 3, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 3, Interval: 2, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 3, Interval: 1501, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 3, Bymonth: []int{1, 3}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 3, Bymonthday: []int{1, 3}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 3, Bymonth: []int{1, 3}, Bymonthday: []int{5, 7}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 3, Byweekday: []RWeekday{TU, TH}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 3, Byweekday: []RWeekday{TU.Nth(1), TH.Nth(-1)}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 3, Bymonth: []int{1, 3}, Byweekday: []RWeekday{TU, TH}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 3, Bymonth: []int{1, 3}, Byweekday: []RWeekday{TU.Nth(1), TH.Nth(-1)}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 3, Bymonthday: []int{1, 3}, Byweekday: []RWeekday{TU, TH}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 3, Bymonth: []int{1, 3}, Bymonthday: []int{1, 3}, Byweekday: []RWeekday{TU, TH}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 4, Byyearday: []int{1, 100, 200, 365}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 4, Byyearday: []int{-365, -266, -166, -1}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 4, Bymonth: []int{4, 7}, Byyearday: []int{1, 100, 200, 365}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 4, Bymonth: []int{4, 7}, Byyearday: []int{-365, -266, -166, -1}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 3, Byweekno: []int{20}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 3, Byweekno: []int{1}, Byweekday: []RWeekday{MO}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 3, Byweekno: []int{52}, Byweekday: []RWeekday{SU}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 3, Byweekno: []int{-1}, Byweekday: []RWeekday{SU}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 3, Byweekno: []int{53}, Byweekday: []RWeekday{MO}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 3, Byeaster: []int{0}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 3, Byeaster: []int{1}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 3, Byeaster: []int{-1}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 3, Byhour: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 3, Byminute: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 3, Bysecond: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 3, Byhour: []int{6, 18}, Byminute: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 3, Byhour: []int{6, 18}, Bysecond: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 3, Byminute: []int{6, 18}, Bysecond: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 3, Byhour: []int{6, 18}, Byminute: []int{6, 18}, Bysecond: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: MINUTELY, Count: 3, Bysecond: []int{15, 30, 45}, Bysetpos: []int{3, -3}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 3, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 3, Interval: 2, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 3, Interval: 90061, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 3, Bymonth: []int{1, 3}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 3, Bymonthday: []int{1, 3}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 3, Bymonth: []int{1, 3}, Bymonthday: []int{5, 7}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 3, Byweekday: []RWeekday{TU, TH}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 3, Byweekday: []RWeekday{TU.Nth(1), TH.Nth(-1)}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 3, Bymonth: []int{1, 3}, Byweekday: []RWeekday{TU, TH}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 3, Bymonth: []int{1, 3}, Byweekday: []RWeekday{TU.Nth(1), TH.Nth(-1)}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 3, Bymonthday: []int{1, 3}, Byweekday: []RWeekday{TU, TH}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 3, Bymonth: []int{1, 3}, Bymonthday: []int{1, 3}, Byweekday: []RWeekday{TU, TH}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 4, Byyearday: []int{1, 100, 200, 365}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 4, Byyearday: []int{-365, -266, -166, -1}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 4, Bymonth: []int{4, 7}, Byyearday: []int{1, 100, 200, 365}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 4, Bymonth: []int{4, 7}, Byyearday: []int{-365, -266, -166, -1}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 3, Byweekno: []int{20}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 3, Byweekno: []int{1}, Byweekday: []RWeekday{MO}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 3, Byweekno: []int{52}, Byweekday: []RWeekday{SU}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 3, Byweekno: []int{-1}, Byweekday: []RWeekday{SU}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 3, Byweekno: []int{53}, Byweekday: []RWeekday{MO}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 3, Byeaster: []int{0}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 3, Byeaster: []int{1}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 3, Byeaster: []int{-1}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 3, Byhour: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 3, Byminute: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 3, Bysecond: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 3, Byhour: []int{6, 18}, Byminute: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 3, Byhour: []int{6, 18}, Bysecond: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 3, Byminute: []int{6, 18}, Bysecond: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 3, Byhour: []int{6, 18}, Byminute: []int{6, 18}, Bysecond: []int{6, 18}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: SECONDLY, Count: 3, Bysecond: []int{0}, Byminute: []int{1}, Dtstart: time.Date(2010, 3, 22, 12, 1, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC), Until: time.Date(1997, 9, 5, 8, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC), Until: time.Date(1997, 9, 4, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC), Until: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC), Until: time.Date(1997, 9, 1, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC), Until: time.Date(1997, 9, 5, 0, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 3, Interval: 2, Byweekday: []RWeekday{TU, SU}, Wkst: MO, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: WEEKLY, Count: 3, Interval: 2, Byweekday: []RWeekday{TU, SU}, Wkst: SU, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Dtstart: time.Date(1997, 9, 2, 0, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Count: 3, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 500000000, time.UTC)},
+	{Freq: DAILY, Dtstart: time.Date(1997, 9, 2, 0, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Dtstart: time.Date(1997, 9, 2, 0, 0, 0, 0, time.UTC), Until: time.Date(1998, 9, 2, 0, 0, 0, 0, time.UTC)},
+	{Freq: MONTHLY, Dtstart: time.Date(MAXYEAR-100, 1, 1, 0, 0, 0, 0, time.UTC)},
+	{Freq: DAILY, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Count: 3, Bymonth: []int{2}, Bymonthday: []int{31}, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY, Dtstart: time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)},
+	{Freq: YEARLY},
+}
+
+func TestRRuleRoundTrip(t *testing.T) {
+	// the rules of the test cases with a DTSTART in a location
+	cet, _ := time.LoadLocation("CET")
+	amsterdam, _ := time.LoadLocation("Europe/Amsterdam")
+	nyLoc, _ := time.LoadLocation("America/New_York")
+	options := append([]ROption{
+		{Freq: DAILY, Count: 10, Wkst: MO, Dtstart: time.Date(2019, 3, 6, 1, 1, 1, 0, cet)},
+		{Freq: DAILY, Count: 3, Dtstart: time.Date(2021, 3, 27, 9, 0, 0, 0, amsterdam)},
+	}, roundTripOptions...)
+	for _, str := range ruleCorpus {
+		option, err := StrToROption(str)
+		if err != nil {
+			t.Fatalf("%s: %s", str, err)
+		}
+		option.Dtstart = time.Date(1997, 9, 2, 9, 0, 0, 0, nyLoc)
+		options = append(options, *option)
+	}
+	for _, option := range options {
+		r, err := NewRRule(option)
+		if err != nil {
+			t.Errorf("%v: %s", option, err)
+			continue
+		}
+		set, err := StrSliceToRRuleSet(r.Recurrence())
+		if err != nil {
+			t.Errorf("%v: %s", r.Recurrence(), err)
+			continue
+		}
+		value := set.GetRRule()[0]
+		if value.RRuleString() != r.RRuleString() {
+			t.Errorf("get %s, want %s", value.RRuleString(), r.RRuleString())
+		}
+		if !value.GetDTStart().Equal(r.GetDTStart()) || value.GetDTStart().Location().String() != r.GetDTStart().Location().String() {
+			t.Errorf("%s: get %v, want %v", r.RRuleString(), value.GetDTStart(), r.GetDTStart())
+		}
+		// not all the rules end, so only their first occurrences are compared
+		if got, want := firstTimes(value.Iterator(), 100), firstTimes(r.Iterator(), 100); !timesEqual(got, want) {
+			t.Errorf("%s: get %v, want %v", r.RRuleString(), got, want)
+		}
+	}
+}
+
+// firstTimes returns the first n times of the iterator
+func firstTimes(next Next, n int) []time.Time {
+	tt := []time.Time{}
+	for v, ok := next(); ok && len(tt) < n; v, ok = next() {
+		tt = append(tt, v)
+	}
+	return tt
+}
+
+func TestRRuleRoundTripWithoutZoneName(t *testing.T) {
+	for _, dtstart := range []time.Time{
+		time.Date(2021, 7, 5, 9, 0, 0, 0, time.Local),
+		time.Date(2021, 7, 5, 9, 0, 0, 0, time.FixedZone("UTC+2", 2*60*60)),
+	} {
+		r, _ := NewRRule(ROption{Freq: DAILY, Count: 3, Dtstart: dtstart})
+		want := "DTSTART:" + dtstart.UTC().Format(DateTimeFormat)
+		if value := r.Recurrence()[0]; value != want {
+			t.Errorf("get %s, want %s", value, want)
+		}
+		set, err := StrSliceToRRuleSet(r.Recurrence())
+		if err != nil {
+			t.Errorf("%v: %s", r.Recurrence(), err)
+			continue
+		}
+		if value := set.All(); !timesEqual(value, r.All()) {
+			t.Errorf("get %v, want %v", value, r.All())
+		}
+	}
+}
+
+func TestRRuleUntilDateRoundTrip(t *testing.T) {
+	option, err := StrToROption("FREQ=DAILY;UNTIL=20240131")
+	if err != nil {
+		t.Fatal(err)
+	}
+	option.Dtstart = time.Date(2024, 1, 29, 0, 0, 0, 0, time.UTC)
+	r, _ := NewRRule(*option)
+	if value := r.RRuleString(); value != "FREQ=DAILY;UNTIL=20240131" {
+		t.Errorf("get %s, want FREQ=DAILY;UNTIL=20240131", value)
+	}
+	set, err := StrSliceToRRuleSet(r.Recurrence())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value := set.All(); len(value) != 3 || !timesEqual(value, r.All()) {
+		t.Errorf("get %v, want %v", value, r.All())
+	}
+}
+
+func TestRRuleStringAfterChange(t *testing.T) {
+	r, _ := StrToRRule("FREQ=YEARLY;COUNT=3;DTSTART=20200315T090000Z")
+	r.DTStart(time.Date(2021, 4, 20, 10, 0, 0, 0, time.UTC))
+	r.Until(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	want := []string{"DTSTART:20210420T100000Z", "RRULE:FREQ=YEARLY;COUNT=3;UNTIL=20300101T000000Z;BYMONTH=3;BYMONTHDAY=15"}
+	if value := r.Recurrence(); len(value) != 2 || value[0] != want[0] || value[1] != want[1] {
+		t.Errorf("get %v, want %v", value, want)
+	}
+	set, _ := StrSliceToRRuleSet(r.Recurrence())
+	if value := set.All(); !timesEqual(value, r.All()) {
+		t.Errorf("get %v, want %v", value, r.All())
+	}
+}
@@ -0,0 +1,309 @@
+package rrule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Language is a translation table for the descriptions of rules in natural language, Words
+// holds the phrases by key (see English for all the keys) and Ordinal spells out a position,
+// where negative positions count from the end
+type Language struct {
+	Words   map[string]string
+	Ordinal func(n int) string
+}
+
+// English describes rules in English, e.g. "every 2 months on the last Friday, until 31 Dec 2026"
+var English = &Language{
+	Words: map[string]string{
+		"every.YEARLY": "every year", "every.n.YEARLY": "every %d years",
+		"every.MONTHLY": "every month", "every.n.MONTHLY": "every %d months",
+		"every.WEEKLY": "every week", "every.n.WEEKLY": "every %d weeks",
+		"every.DAILY": "every day", "every.n.DAILY": "every %d days",
+		"every.HOURLY": "every hour", "every.n.HOURLY": "every %d hours",
+		"every.MINUTELY": "every minute", "every.n.MINUTELY": "every %d minutes",
+		"every.SECONDLY": "every second", "every.n.SECONDLY": "every %d seconds",
+		"and": "and", "on": "on %s", "nth.weekday": "the %s %s", "weekdays": "on weekdays",
+		"months": "in %s", "weeks": "in week %s", "yeardays": "on the %s day of the year",
+		"monthdays": "on the %s day", "easter": "Easter Sunday",
+		"easter.after": "%s after Easter Sunday", "easter.before": "%s before Easter Sunday",
+		"day": "%d day", "days": "%d days", "times": "at %s", "minutes": "at minute %s",
+		"setpos": "only the %s occurrence", "count.1": "once", "count": "%d times",
+		"until": "until %s", "date": "%d %s %d",
+		"MO": "Monday", "TU": "Tuesday", "WE": "Wednesday", "TH": "Thursday",
+		"FR": "Friday", "SA": "Saturday", "SU": "Sunday",
+		"January": "January", "February": "February", "March": "March", "April": "April",
+		"May": "May", "June": "June", "July": "July", "August": "August",
+		"September": "September", "October": "October", "November": "November", "December": "December",
+		"Jan": "Jan", "Feb": "Feb", "Mar": "Mar", "Apr": "Apr", "Jun": "Jun", "Jul": "Jul",
+		"Aug": "Aug", "Sep": "Sep", "Oct": "Oct", "Nov": "Nov", "Dec": "Dec",
+	},
+	Ordinal: englishOrdinal,
+}
+
+// Dutch describes rules in Dutch, e.g. "elke 2 maanden op de laatste vrijdag, tot 31 dec 2026"
+var Dutch = &Language{
+	Words: map[string]string{
+		"every.YEARLY": "elk jaar", "every.n.YEARLY": "elke %d jaar",
+		"every.MONTHLY": "elke maand", "every.n.MONTHLY": "elke %d maanden",
+		"every.WEEKLY": "elke week", "every.n.WEEKLY": "elke %d weken",
+		"every.DAILY": "elke dag", "every.n.DAILY": "elke %d dagen",
+		"every.HOURLY": "elk uur", "every.n.HOURLY": "elke %d uur",
+		"every.MINUTELY": "elke minuut", "every.n.MINUTELY": "elke %d minuten",
+		"every.SECONDLY": "elke seconde", "every.n.SECONDLY": "elke %d seconden",
+		"and": "en", "on": "op %s", "nth.weekday": "de %s %s", "weekdays": "op werkdagen",
+		"months": "in %s", "weeks": "in week %s", "yeardays": "op de %s dag van het jaar",
+		"monthdays": "op de %s dag", "easter": "paaszondag",
+		"easter.after": "%s na paaszondag", "easter.before": "%s voor paaszondag",
+		"day": "%d dag", "days": "%d dagen", "times": "om %s", "minutes": "op minuut %s",
+		"setpos": "alleen de %s keer", "count.1": "1 keer", "count": "%d keer",
+		"until": "tot %s", "date": "%d %s %d",
+		"MO": "maandag", "TU": "dinsdag", "WE": "woensdag", "TH": "donderdag",
+		"FR": "vrijdag", "SA": "zaterdag", "SU": "zondag",
+		"January": "januari", "February": "februari", "March": "maart", "April": "april",
+		"May": "mei", "June": "juni", "July": "juli", "August": "augustus",
+		"September": "september", "October": "oktober", "November": "november", "December": "december",
+		"Jan": "jan", "Feb": "feb", "Mar": "mrt", "Apr": "apr", "Jun": "jun", "Jul": "jul",
+		"Aug": "aug", "Sep": "sep", "Oct": "okt", "Nov": "nov", "Dec": "dec",
+	},
+	Ordinal: dutchOrdinal,
+}
+
+// German describes rules in German, e.g. "alle 2 Monate am letzten Freitag, bis 31. Dez. 2026"
+var German = &Language{
+	Words: map[string]string{
+		"every.YEARLY": "jedes Jahr", "every.n.YEARLY": "alle %d Jahre",
+		"every.MONTHLY": "jeden Monat", "every.n.MONTHLY": "alle %d Monate",
+		"every.WEEKLY": "jede Woche", "every.n.WEEKLY": "alle %d Wochen",
+		"every.DAILY": "jeden Tag", "every.n.DAILY": "alle %d Tage",
+		"every.HOURLY": "jede Stunde", "every.n.HOURLY": "alle %d Stunden",
+		"every.MINUTELY": "jede Minute", "every.n.MINUTELY": "alle %d Minuten",
+		"every.SECONDLY": "jede Sekunde", "every.n.SECONDLY": "alle %d Sekunden",
+		"and": "und", "on": "am %s", "nth.weekday": "%s %s", "weekdays": "an Werktagen",
+		"months": "im %s", "weeks": "in Woche %s", "yeardays": "am %s Tag des Jahres",
+		"monthdays": "am %s Tag", "easter": "Ostersonntag",
+		"easter.after": "%s nach Ostersonntag", "easter.before": "%s vor Ostersonntag",
+		"day": "%d Tag", "days": "%d Tage", "times": "um %s", "minutes": "in Minute %s",
+		"setpos": "nur den %s Termin", "count.1": "einmal", "count": "%d Mal",
+		"until": "bis %s", "date": "%d. %s %d",
+		"MO": "Montag", "TU": "Dienstag", "WE": "Mittwoch", "TH": "Donnerstag",
+		"FR": "Freitag", "SA": "Samstag", "SU": "Sonntag",
+		"January": "Januar", "February": "Februar", "March": "März", "April": "April",
+		"May": "Mai", "June": "Juni", "July": "Juli", "August": "August",
+		"September": "September", "October": "Oktober", "November": "November", "December": "Dezember",
+		"Jan": "Jan.", "Feb": "Feb.", "Mar": "März", "Apr": "Apr.", "Jun": "Juni", "Jul": "Juli",
+		"Aug": "Aug.", "Sep": "Sept.", "Oct": "Okt.", "Nov": "Nov.", "Dec": "Dez.",
+	},
+	Ordinal: germanOrdinal,
+}
+
+// englishOrdinal spells out a position in English, e.g. "first", "15th" and "last"
+func englishOrdinal(n int) string {
+	words := map[int]string{1: "first", 2: "second", 3: "third", 4: "fourth", 5: "fifth",
+		-1: "last", -2: "second to last", -3: "third to last"}
+	if word, ok := words[n]; ok {
+		return word
+	}
+	if n < 0 {
+		return englishOrdinal(-n) + " to last"
+	}
+	suffix := "th"
+	if n%100 < 11 || n%100 > 13 {
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return strconv.Itoa(n) + suffix
+}
+
+// dutchOrdinal spells out a position in Dutch, e.g. "eerste", "15e" and "laatste"
+func dutchOrdinal(n int) string {
+	words := map[int]string{1: "eerste", 2: "tweede", 3: "derde", 4: "vierde", 5: "vijfde",
+		-1: "laatste", -2: "voorlaatste"}
+	if word, ok := words[n]; ok {
+		return word
+	}
+	if n < 0 {
+		return fmt.Sprintf("op %d na laatste", -n-1)
+	}
+	return strconv.Itoa(n) + "e"
+}
+
+// germanOrdinal spells out a position in German in the dative, e.g. "ersten", "15." and "letzten"
+func germanOrdinal(n int) string {
+	words := map[int]string{1: "ersten", 2: "zweiten", 3: "dritten", 4: "vierten", 5: "fünften",
+		-1: "letzten", -2: "vorletzten", -3: "drittletzten"}
+	if word, ok := words[n]; ok {
+		return word
+	}
+	if n < 0 {
+		return fmt.Sprintf("%d.-letzten", -n)
+	}
+	return strconv.Itoa(n) + "."
+}
+
+// Text returns the description of the options in natural language, only the parts that
+// are given are described, e.g. DTSTART and WKST are not
+func (option *ROption) Text(language *Language) string {
+	l := language
+	parts := []string{}
+	if option.Interval > 1 {
+		parts = append(parts, l.format("every.n."+option.Freq.String(), option.Interval))
+	} else {
+		parts = append(parts, l.word("every."+option.Freq.String()))
+	}
+	if len(option.Bymonth) != 0 {
+		months := make([]string, len(option.Bymonth))
+		for i, month := range option.Bymonth {
+			months[i] = l.word(time.Month(month).String())
+		}
+		parts = append(parts, l.format("months", l.list(months)))
+	}
+	if len(option.Byweekno) != 0 {
+		weeks := make([]string, len(option.Byweekno))
+		for i, week := range option.Byweekno {
+			weeks[i] = strconv.Itoa(week)
+		}
+		parts = append(parts, l.format("weeks", l.list(weeks)))
+	}
+	if len(option.Byyearday) != 0 {
+		parts = append(parts, l.format("yeardays", l.list(l.ordinals(option.Byyearday))))
+	}
+	if len(option.Bymonthday) != 0 {
+		parts = append(parts, l.format("monthdays", l.list(l.ordinals(option.Bymonthday))))
+	}
+	if len(option.Byweekday) != 0 {
+		parts = append(parts, l.weekdays(option.Byweekday))
+	}
+	if len(option.Byeaster) != 0 {
+		parts = append(parts, l.easter(option.Byeaster))
+	}
+	if len(option.Byhour) != 0 {
+		parts = append(parts, l.format("times", l.list(times(option))))
+	} else if len(option.Byminute) != 0 {
+		minutes := make([]string, len(option.Byminute))
+		for i, minute := range option.Byminute {
+			minutes[i] = strconv.Itoa(minute)
+		}
+		parts = append(parts, l.format("minutes", l.list(minutes)))
+	}
+	if len(option.Bysetpos) != 0 {
+		parts = append(parts, l.format("setpos", l.list(l.ordinals(option.Bysetpos))))
+	}
+
+	text := strings.Join(parts, " ")
+	if option.Count == 1 {
+		text += ", " + l.word("count.1")
+	} else if option.Count != 0 {
+		text += ", " + l.format("count", option.Count)
+	}
+	if !option.Until.IsZero() {
+		until := l.format("date", option.Until.Day(), l.word(option.Until.Month().String()[:3]), option.Until.Year())
+		text += ", " + l.format("until", until)
+	}
+	return text
+}
+
+// Text returns the description of the rule in its current state in natural language
+func (r *RRule) Text(language *Language) string {
+	option := r.options()
+	return option.Text(language)
+}
+
+// word returns the phrase for the key
+func (l *Language) word(key string) string {
+	return l.Words[key]
+}
+
+// format returns the phrase for the key with the arguments filled in, a phrase may leave
+// out the arguments
+func (l *Language) format(key string, args ...interface{}) string {
+	phrase := l.Words[key]
+	if !strings.Contains(phrase, "%") {
+		return phrase
+	}
+	return fmt.Sprintf(phrase, args...)
+}
+
+// list joins the items with commas and the word for "and" before the last item
+func (l *Language) list(items []string) string {
+	if len(items) < 2 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " " + l.word("and") + " " + items[len(items)-1]
+}
+
+// ordinals spells out the positions
+func (l *Language) ordinals(positions []int) []string {
+	ordinals := make([]string, len(positions))
+	for i, n := range positions {
+		ordinals[i] = l.Ordinal(n)
+	}
+	return ordinals
+}
+
+// weekdays describes the weekdays, Monday till Friday are described as weekdays
+func (l *Language) weekdays(wdays []RWeekday) string {
+	plain := map[int]bool{}
+	days := make([]string, len(wdays))
+	for i, wday := range wdays {
+		name := l.word(RWeekday{weekday: wday.weekday}.String())
+		if wday.n == 0 {
+			plain[wday.weekday] = true
+			days[i] = name
+		} else {
+			days[i] = l.format("nth.weekday", l.Ordinal(wday.n), name)
+		}
+	}
+	if len(plain) == 5 && len(wdays) == 5 && !plain[SA.weekday] && !plain[SU.weekday] {
+		return l.word("weekdays")
+	}
+	return l.format("on", l.list(days))
+}
+
+// easter describes the days relative to Easter Sunday
+func (l *Language) easter(offsets []int) string {
+	days := make([]string, len(offsets))
+	for i, offset := range offsets {
+		n := offset
+		if n < 0 {
+			n = -n
+		}
+		count := l.format("days", n)
+		if n == 1 {
+			count = l.format("day", n)
+		}
+		switch {
+		case offset == 0:
+			days[i] = l.format("on", l.word("easter"))
+		case offset > 0:
+			days[i] = l.format("easter.after", count)
+		default:
+			days[i] = l.format("easter.before", count)
+		}
+	}
+	return l.list(days)
+}
+
+// times returns the times of the day of the options as hour:minute, without BYMINUTE the
+// minute of DTSTART is used
+func times(option *ROption) []string {
+	minutes := option.Byminute
+	if len(minutes) == 0 {
+		minutes = []int{option.Dtstart.Minute()}
+	}
+	times := []string{}
+	for _, hour := range option.Byhour {
+		for _, minute := range minutes {
+			times = append(times, fmt.Sprintf("%d:%02d", hour, minute))
+		}
+	}
+	return times
+}
@@ -0,0 +1,92 @@
+package rrule
+
+import (
+	"testing"
+)
+
+func TestText(t *testing.T) {
+	tests := []struct {
+		rrule   string
+		english string
+		dutch   string
+		german  string
+	}{
+		{"FREQ=MONTHLY;BYDAY=-1FR;INTERVAL=2;UNTIL=20261231T000000Z",
+			"every 2 months on the last Friday, until 31 Dec 2026",
+			"elke 2 maanden op de laatste vrijdag, tot 31 dec 2026",
+			"alle 2 Monate am letzten Freitag, bis 31. Dez. 2026"},
+		{"FREQ=DAILY",
+			"every day",
+			"elke dag",
+			"jeden Tag"},
+		{"FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR",
+			"every week on weekdays",
+			"elke week op werkdagen",
+			"jede Woche an Werktagen"},
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10",
+			"every 2 weeks on Monday and Wednesday, 10 times",
+			"elke 2 weken op maandag en woensdag, 10 keer",
+			"alle 2 Wochen am Montag und Mittwoch, 10 Mal"},
+		{"FREQ=YEARLY;BYMONTH=1,3;BYDAY=1TU,-1TH;COUNT=1",
+			"every year in January and March on the first Tuesday and the last Thursday, once",
+			"elk jaar in januari en maart op de eerste dinsdag en de laatste donderdag, 1 keer",
+			"jedes Jahr im Januar und März am ersten Dienstag und letzten Donnerstag, einmal"},
+		{"FREQ=MONTHLY;BYMONTHDAY=1,15,-1",
+			"every month on the first, 15th and last day",
+			"elke maand op de eerste, 15e en laatste dag",
+			"jeden Monat am ersten, 15. und letzten Tag"},
+		{"FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-2",
+			"every month on weekdays only the second to last occurrence",
+			"elke maand op werkdagen alleen de voorlaatste keer",
+			"jeden Monat an Werktagen nur den vorletzten Termin"},
+		{"FREQ=YEARLY;BYEASTER=0,-2",
+			"every year on Easter Sunday and 2 days before Easter Sunday",
+			"elk jaar op paaszondag en 2 dagen voor paaszondag",
+			"jedes Jahr am Ostersonntag und 2 Tage vor Ostersonntag"},
+		{"FREQ=YEARLY;BYWEEKNO=20;BYYEARDAY=100",
+			"every year in week 20 on the 100th day of the year",
+			"elk jaar in week 20 op de 100e dag van het jaar",
+			"jedes Jahr in Woche 20 am 100. Tag des Jahres"},
+		{"FREQ=DAILY;BYHOUR=9,17;BYMINUTE=30",
+			"every day at 9:30 and 17:30",
+			"elke dag om 9:30 en 17:30",
+			"jeden Tag um 9:30 und 17:30"},
+		{"FREQ=HOURLY;INTERVAL=3;BYMINUTE=0,30",
+			"every 3 hours at minute 0 and 30",
+			"elke 3 uur op minuut 0 en 30",
+			"alle 3 Stunden in Minute 0 und 30"},
+	}
+	for _, test := range tests {
+		option, err := StrToROption(test.rrule)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range []struct {
+			language *Language
+			text     string
+		}{{English, test.english}, {Dutch, test.dutch}, {German, test.german}} {
+			if value := option.Text(want.language); value != want.text {
+				t.Errorf("%s: get %q, want %q", test.rrule, value, want.text)
+			}
+		}
+	}
+}
+
+func TestTextCustomLanguage(t *testing.T) {
+	words := map[string]string{}
+	for key, word := range English.Words {
+		words[key] = word
+	}
+	words["every.WEEKLY"] = "weekly"
+	words["count"] = "a few times"
+	words["TU"] = "Tue"
+	shorter := &Language{Words: words, Ordinal: English.Ordinal}
+
+	r, _ := StrToRRule("FREQ=WEEKLY;COUNT=3;BYDAY=TU;DTSTART=20210706T090000Z")
+	if value := r.Text(shorter); value != "weekly on Tue, a few times" {
+		t.Errorf("get %q, want %q", value, "weekly on Tue, a few times")
+	}
+	if value := r.Text(English); value != "every week on Tuesday, 3 times" {
+		t.Errorf("get %q, want %q", value, "every week on Tuesday, 3 times")
+	}
+}
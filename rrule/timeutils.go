@@ -4,17 +4,37 @@ import (
 	"time"
 )
 
+// BeginningOfMinute returns the start of the minute of t, it is taken from the offset of t so
+// that it is right for any offset and in the hour that is repeated when the clocks go back
 func BeginningOfMinute(t time.Time) time.Time {
-	return t.Truncate(time.Minute)
+	return t.Add(-time.Duration(t.Second())*time.Second - time.Duration(t.Nanosecond()))
 }
 
+// BeginningOfHour returns the start of the hour of t in the same way as BeginningOfMinute
 func BeginningOfHour(t time.Time) time.Time {
-	return t.Truncate(time.Hour)
+	return BeginningOfMinute(t).Add(-time.Duration(t.Minute()) * time.Minute)
 }
 
+// BeginningOfDay returns the start of the day of t in its location, days are calendar days
+// so that a day around a daylight saving time transition is 23 or 25 hours long
 func BeginningOfDay(t time.Time) time.Time {
-	d := time.Duration(-t.Hour()) * time.Hour
-	return BeginningOfHour(t).Add(d)
+	return startOfDay(t.Year(), t.Month(), t.Day(), t.Location())
+}
+
+// startOfDay returns midnight of the day, when the clocks move forward at midnight the day
+// starts at the end of the skipped hour
+func startOfDay(year int, month time.Month, day int, loc *time.Location) time.Time {
+	t := time.Date(year, month, day, 0, 0, 0, 0, loc)
+	if t.Day() != time.Date(year, month, day, 12, 0, 0, 0, loc).Day() {
+		_, end := t.ZoneBounds()
+		return end
+	}
+	return t
+}
+
+// nextDay returns the start of the day after the day of t
+func nextDay(t time.Time) time.Time {
+	return startOfDay(t.Year(), t.Month(), t.Day()+1, t.Location())
 }
 
 func BeginningOfWeek(t time.Time) time.Time {
@@ -24,14 +44,11 @@ func BeginningOfWeek(t time.Time) time.Time {
 		weekday = 7
 	}
 	weekday = weekday - 1
-	d := time.Duration(-weekday) * 24 * time.Hour
-	return t.Add(d)
+	return startOfDay(t.Year(), t.Month(), t.Day()-weekday, t.Location())
 }
 
 func BeginningOfMonth(t time.Time) time.Time {
-	t = BeginningOfDay(t)
-	d := time.Duration(-int(t.Day())+1) * 24 * time.Hour
-	return t.Add(d)
+	return startOfDay(t.Year(), t.Month(), 1, t.Location())
 }
 
 func BeginningOfQuarter(t time.Time) time.Time {
@@ -41,9 +58,7 @@ func BeginningOfQuarter(t time.Time) time.Time {
 }
 
 func BeginningOfYear(t time.Time) time.Time {
-	t = BeginningOfDay(t)
-	d := time.Duration(-int(t.YearDay())+1) * 24 * time.Hour
-	return t.Truncate(time.Hour).Add(d)
+	return startOfDay(t.Year(), time.January, 1, t.Location())
 }
 
 func EndOfMinute(t time.Time) time.Time {
@@ -55,7 +70,7 @@ func EndOfHour(t time.Time) time.Time {
 }
 
 func EndOfDay(t time.Time) time.Time {
-	return BeginningOfDay(t).Add(24*time.Hour - time.Nanosecond)
+	return nextDay(t).Add(-time.Nanosecond)
 }
 
 func EndOfWeek(t time.Time) time.Time {
@@ -80,8 +95,7 @@ func AsMonday(t time.Time) time.Time {
 	if weekday == 0 {
 		weekday = 7
 	}
-	d := time.Duration(-weekday+1) * 24 * time.Hour
-	return t.Truncate(time.Hour).Add(d)
+	return startOfDay(t.Year(), t.Month(), t.Day()-weekday+1, t.Location())
 }
 
 func AsSunday(t time.Time) time.Time {
@@ -90,13 +104,12 @@ func AsSunday(t time.Time) time.Time {
 	if weekday == 0 {
 		return t
 	} else {
-		d := time.Duration(7-weekday) * 24 * time.Hour
-		return t.Truncate(time.Hour).Add(d)
+		return startOfDay(t.Year(), t.Month(), t.Day()+7-weekday, t.Location())
 	}
 }
 
 func EndOfSunday(t time.Time) time.Time {
-	return AsSunday(t).Add(24*time.Hour - time.Nanosecond)
+	return nextDay(AsSunday(t)).Add(-time.Nanosecond)
 }
 
 func WeekOfMonth(t time.Time) int {
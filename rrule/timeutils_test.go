@@ -0,0 +1,68 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDayArithmeticAcrossDST(t *testing.T) {
+	amsterdam, _ := time.LoadLocation("Europe/Amsterdam")
+	saoPaulo, _ := time.LoadLocation("America/Sao_Paulo")
+	kolkata, _ := time.LoadLocation("Asia/Kolkata")
+	tests := []struct {
+		t    time.Time
+		fn   func(time.Time) time.Time
+		want time.Time
+	}{
+		// the clocks move forward on Sunday 28 March 2021 in Amsterdam
+		{time.Date(2021, 3, 28, 12, 0, 0, 0, amsterdam), BeginningOfDay, time.Date(2021, 3, 28, 0, 0, 0, 0, amsterdam)},
+		{time.Date(2021, 3, 28, 12, 0, 0, 0, amsterdam), EndOfDay, time.Date(2021, 3, 28, 23, 59, 59, 999999999, amsterdam)},
+		{time.Date(2021, 3, 31, 12, 0, 0, 0, amsterdam), BeginningOfWeek, time.Date(2021, 3, 29, 0, 0, 0, 0, amsterdam)},
+		{time.Date(2021, 3, 31, 12, 0, 0, 0, amsterdam), BeginningOfMonth, time.Date(2021, 3, 1, 0, 0, 0, 0, amsterdam)},
+		{time.Date(2021, 4, 2, 12, 0, 0, 0, amsterdam), BeginningOfMonth, time.Date(2021, 4, 1, 0, 0, 0, 0, amsterdam)},
+		{time.Date(2021, 7, 2, 12, 0, 0, 0, amsterdam), BeginningOfYear, time.Date(2021, 1, 1, 0, 0, 0, 0, amsterdam)},
+		{time.Date(2021, 3, 30, 12, 0, 0, 0, amsterdam), AsMonday, time.Date(2021, 3, 29, 0, 0, 0, 0, amsterdam)},
+		{time.Date(2021, 3, 24, 12, 0, 0, 0, amsterdam), AsSunday, time.Date(2021, 3, 28, 0, 0, 0, 0, amsterdam)},
+		// the clocks move back on Sunday 31 October 2021 in Amsterdam
+		{time.Date(2021, 11, 3, 12, 0, 0, 0, amsterdam), BeginningOfWeek, time.Date(2021, 11, 1, 0, 0, 0, 0, amsterdam)},
+		{time.Date(2021, 10, 27, 12, 0, 0, 0, amsterdam), EndOfSunday, time.Date(2021, 10, 31, 23, 59, 59, 999999999, amsterdam)},
+		// the clocks moved forward at midnight of 4 November 2018 in Sao Paulo
+		{time.Date(2018, 11, 4, 12, 0, 0, 0, saoPaulo), BeginningOfDay, time.Date(2018, 11, 4, 1, 0, 0, 0, saoPaulo)},
+		{time.Date(2018, 11, 7, 12, 0, 0, 0, saoPaulo), BeginningOfWeek, time.Date(2018, 11, 5, 0, 0, 0, 0, saoPaulo)},
+		{time.Date(2018, 11, 1, 12, 0, 0, 0, saoPaulo), AsSunday, time.Date(2018, 11, 4, 1, 0, 0, 0, saoPaulo)},
+		{time.Date(2018, 11, 3, 12, 0, 0, 0, saoPaulo), EndOfDay, time.Date(2018, 11, 4, 1, 0, 0, 0, saoPaulo).Add(-time.Nanosecond)},
+		{time.Date(2018, 11, 30, 12, 0, 0, 0, saoPaulo), BeginningOfMonth, time.Date(2018, 11, 1, 0, 0, 0, 0, saoPaulo)},
+		// the clocks moved back at midnight of 17 February 2019 in Sao Paulo
+		{time.Date(2019, 2, 20, 12, 0, 0, 0, saoPaulo), BeginningOfWeek, time.Date(2019, 2, 18, 0, 0, 0, 0, saoPaulo)},
+		{time.Date(2019, 2, 16, 12, 0, 0, 0, saoPaulo), EndOfDay, time.Date(2019, 2, 17, 0, 0, 0, 0, saoPaulo).Add(-time.Nanosecond)},
+		// the second 02:30 of 31 October 2021 in Amsterdam is in the hour that is repeated
+		{time.Date(2021, 10, 31, 1, 30, 15, 0, time.UTC).In(amsterdam), BeginningOfHour, time.Date(2021, 10, 31, 1, 0, 0, 0, time.UTC)},
+		{time.Date(2021, 10, 31, 1, 30, 15, 0, time.UTC).In(amsterdam), BeginningOfMinute, time.Date(2021, 10, 31, 1, 30, 0, 0, time.UTC)},
+		// the offset of Kolkata is five and a half hours
+		{time.Date(2021, 7, 5, 9, 45, 0, 0, kolkata), BeginningOfHour, time.Date(2021, 7, 5, 9, 0, 0, 0, kolkata)},
+	}
+	for _, test := range tests {
+		if value := test.fn(test.t); !value.Equal(test.want) {
+			t.Errorf("%v: get %v, want %v", test.t, value, test.want)
+		}
+	}
+}
+
+func TestNextNAcrossDST(t *testing.T) {
+	amsterdam, _ := time.LoadLocation("Europe/Amsterdam")
+	saoPaulo, _ := time.LoadLocation("America/Sao_Paulo")
+	for _, start := range []time.Time{
+		time.Date(2021, 3, 26, 0, 0, 0, 0, amsterdam),
+		time.Date(2021, 10, 29, 0, 0, 0, 0, amsterdam),
+		time.Date(2018, 11, 2, 0, 0, 0, 0, saoPaulo),
+		time.Date(2019, 2, 15, 0, 0, 0, 0, saoPaulo),
+	} {
+		value := NextNBefore(start, Always, 5, start.AddDate(0, 0, 10))
+		for i, day := range value {
+			want := time.Date(start.Year(), start.Month(), start.Day()+i, 12, 0, 0, 0, start.Location())
+			if y, m, d := day.Date(); y != want.Year() || m != want.Month() || d != want.Day() {
+				t.Errorf("get %v, want %v", day, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,291 @@
+package rrule
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// TimezoneMaxYear is the last year for which transitions of a compiled timezone are generated
+const TimezoneMaxYear = 2100
+
+// Observance describes a STANDARD or DAYLIGHT sub-component of a VTIMEZONE
+type Observance struct {
+	Name       string      // TZNAME
+	Daylight   bool        // true for a DAYLIGHT observance
+	Start      time.Time   // DTSTART, the wall clock is used and the location is ignored
+	OffsetFrom int         // TZOFFSETFROM in seconds east of UTC
+	OffsetTo   int         // TZOFFSETTO in seconds east of UTC
+	RRule      string      // RRULE value, empty when the observance has a single onset
+	RDates     []time.Time // RDATE values, like Start only the wall clock is used
+}
+
+type transition struct {
+	when time.Time
+	zone int
+}
+
+type zoneType struct {
+	offset int
+	isDST  bool
+	name   string
+}
+
+// CompileTimezone builds a *time.Location from the observances of a VTIMEZONE,
+// the transitions are generated up to TimezoneMaxYear
+func CompileTimezone(tzid string, observances []Observance) (*time.Location, error) {
+	if len(observances) == 0 {
+		return nil, fmt.Errorf("timezone %s has no observances", tzid)
+	}
+
+	zones := []zoneType{}
+	zoneIndex := func(z zoneType) int {
+		for i, zz := range zones {
+			if zz == z {
+				return i
+			}
+		}
+		zones = append(zones, z)
+		return len(zones) - 1
+	}
+
+	// The zone in effect before the first onset, it is kept apart so that it is
+	// used for all the times before the first transition.
+	first := observances[0]
+	for _, o := range observances[1:] {
+		if o.Start.Before(first.Start) {
+			first = o
+		}
+	}
+	before := zoneType{offset: first.OffsetFrom, isDST: !first.Daylight, name: offsetName(first.OffsetFrom)}
+	for _, o := range observances {
+		if o.OffsetTo == first.OffsetFrom && o.Name != "" {
+			before.name = o.Name
+		}
+	}
+	zones = append(zones, before)
+
+	transitions := []transition{}
+	for _, o := range observances {
+		onsets, err := o.onsets(TimezoneMaxYear)
+		if err != nil {
+			return nil, fmt.Errorf("timezone %s: %s", tzid, err)
+		}
+		name := o.Name
+		if name == "" {
+			name = offsetName(o.OffsetTo)
+		}
+		zone := zoneIndex(zoneType{offset: o.OffsetTo, isDST: o.Daylight, name: name})
+		for _, onset := range onsets {
+			// the onset is the wall clock time before the transition
+			when := onset.Add(-time.Duration(o.OffsetFrom) * time.Second)
+			transitions = append(transitions, transition{when: when, zone: zone})
+		}
+	}
+	sort.SliceStable(transitions, func(i, j int) bool { return transitions[i].when.Before(transitions[j].when) })
+
+	return time.LoadLocationFromTZData(tzid, tzData(zones, transitions))
+}
+
+// onsets returns the wall clock times at which the observance takes effect
+func (o *Observance) onsets(maxYear int) ([]time.Time, error) {
+	start := time.Date(o.Start.Year(), o.Start.Month(), o.Start.Day(), o.Start.Hour(), o.Start.Minute(), o.Start.Second(), 0, time.UTC)
+	onsets := []time.Time{start}
+	for _, rdate := range o.RDates {
+		onsets = append(onsets, time.Date(rdate.Year(), rdate.Month(), rdate.Day(), rdate.Hour(), rdate.Minute(), rdate.Second(), 0, time.UTC))
+	}
+	if o.RRule == "" {
+		return onsets, nil
+	}
+
+	option, err := StrToROption(o.RRule)
+	if err != nil {
+		return nil, err
+	}
+	if option.Freq != YEARLY {
+		return nil, fmt.Errorf("unsupported observance frequency %v", option.Freq)
+	}
+	interval := option.Interval
+	if interval == 0 {
+		interval = 1
+	}
+	months := option.Bymonth
+	if len(months) == 0 {
+		months = []int{int(start.Month())}
+	}
+	sort.Ints(months)
+
+	// DTSTART is the first instance of the rule
+	count := 1
+	for year := start.Year(); year <= maxYear; year += interval {
+		for _, month := range months {
+			for _, day := range observanceDays(year, time.Month(month), option, start) {
+				onset := time.Date(year, time.Month(month), day, start.Hour(), start.Minute(), start.Second(), 0, time.UTC)
+				if !onset.After(start) {
+					continue
+				}
+				if !option.Until.IsZero() && onset.Add(-time.Duration(o.OffsetFrom)*time.Second).After(option.Until) {
+					return onsets, nil
+				}
+				if option.Count != 0 && count >= option.Count {
+					return onsets, nil
+				}
+				onsets = append(onsets, onset)
+				count++
+			}
+		}
+	}
+	return onsets, nil
+}
+
+// observanceDays returns the days of the month selected by the BYDAY and
+// BYMONTHDAY parts of a yearly observance rule
+func observanceDays(year int, month time.Month, option *ROption, start time.Time) []int {
+	last := daysIn(month, year)
+	weekdays := []time.Weekday{}
+	days := []int{}
+	for _, wday := range option.Byweekday {
+		weekday := time.Weekday((wday.weekday + 1) % 7)
+		if wday.n == 0 {
+			weekdays = append(weekdays, weekday)
+			continue
+		}
+		if day := nthWeekdayOfMonth(year, month, weekday, wday.n); day > 0 {
+			days = append(days, day)
+		}
+	}
+
+	monthdays := []int{}
+	for _, day := range option.Bymonthday {
+		if day < 0 {
+			day = last + day + 1
+		}
+		if day >= 1 && day <= last {
+			monthdays = append(monthdays, day)
+		}
+	}
+	if len(monthdays) == 0 && len(weekdays) != 0 {
+		monthdays = rang(1, last+1)
+	} else if len(monthdays) == 0 && len(days) == 0 {
+		monthdays = []int{start.Day()}
+	}
+	for _, day := range monthdays {
+		weekday := time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday()
+		if len(weekdays) == 0 || weekdayIn(weekdays, weekday) {
+			days = append(days, day)
+		}
+	}
+	sort.Ints(days)
+	return days
+}
+
+// nthWeekdayOfMonth returns the day of the n-th weekday in the month, negative
+// n counts from the end of the month, 0 is returned when there is no such day
+func nthWeekdayOfMonth(year int, month time.Month, weekday time.Weekday, n int) int {
+	last := daysIn(month, year)
+	var day int
+	if n > 0 {
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC).Weekday()
+		day = 1 + pymod(int(weekday)-int(first), 7) + (n-1)*7
+	} else {
+		lastWeekday := time.Date(year, month, last, 0, 0, 0, 0, time.UTC).Weekday()
+		day = last - pymod(int(lastWeekday)-int(weekday), 7) + (n+1)*7
+	}
+	if day < 1 || day > last {
+		return 0
+	}
+	return day
+}
+
+func weekdayIn(weekdays []time.Weekday, weekday time.Weekday) bool {
+	for _, wd := range weekdays {
+		if wd == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// offsetName formats an offset in seconds as '+hhmm', used when there is no TZNAME
+func offsetName(offset int) string {
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	return fmt.Sprintf("%c%02d%02d", sign, offset/3600, (offset%3600)/60)
+}
+
+// ParseUTCOffset parses an UTC-OFFSET value ('+hhmm', '-hhmm' or '+hhmmss')
+// and returns the offset in seconds east of UTC
+func ParseUTCOffset(str string) (int, error) {
+	if (len(str) != 5 && len(str) != 7) || (str[0] != '+' && str[0] != '-') {
+		return 0, errors.New("bad UTC offset: " + str)
+	}
+	fields := []int{}
+	for i := 1; i < len(str); i += 2 {
+		if str[i] < '0' || str[i] > '9' || str[i+1] < '0' || str[i+1] > '9' {
+			return 0, errors.New("bad UTC offset: " + str)
+		}
+		fields = append(fields, int(str[i]-'0')*10+int(str[i+1]-'0'))
+	}
+	offset := fields[0]*3600 + fields[1]*60
+	if len(fields) == 3 {
+		offset += fields[2]
+	}
+	if str[0] == '-' {
+		offset = -offset
+	}
+	return offset, nil
+}
+
+// tzData encodes zones and transitions in the (version 2) TZif format that is
+// understood by time.LoadLocationFromTZData
+func tzData(zones []zoneType, transitions []transition) []byte {
+	chars := []byte{}
+	nameIndex := make([]int, len(zones))
+	for i, z := range zones {
+		if at := bytes.Index(chars, append([]byte(z.name), 0)); at >= 0 {
+			nameIndex[i] = at
+			continue
+		}
+		nameIndex[i] = len(chars)
+		chars = append(chars, z.name...)
+		chars = append(chars, 0)
+	}
+
+	buf := &bytes.Buffer{}
+	header := func(timecnt, typecnt, charcnt int) {
+		buf.WriteString("TZif2")
+		buf.Write(make([]byte, 15))
+		for _, n := range []int{0, 0, 0, timecnt, typecnt, charcnt} {
+			binary.Write(buf, binary.BigEndian, uint32(n))
+		}
+	}
+
+	// an empty version 1 block, readers that understand version 2 skip it
+	header(0, 0, 0)
+
+	header(len(transitions), len(zones), len(chars))
+	for _, t := range transitions {
+		binary.Write(buf, binary.BigEndian, t.when.Unix())
+	}
+	for _, t := range transitions {
+		buf.WriteByte(byte(t.zone))
+	}
+	for i, z := range zones {
+		binary.Write(buf, binary.BigEndian, int32(z.offset))
+		if z.isDST {
+			buf.WriteByte(1)
+		} else {
+			buf.WriteByte(0)
+		}
+		buf.WriteByte(byte(nameIndex[i]))
+	}
+	buf.Write(chars)
+	buf.WriteString("\n\n")
+	return buf.Bytes()
+}
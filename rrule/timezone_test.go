@@ -0,0 +1,133 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCompileTimezone(t *testing.T) {
+	observances := []Observance{
+		{Name: "EEST", Daylight: true, Start: time.Date(1970, 3, 29, 3, 0, 0, 0, time.UTC), OffsetFrom: 2 * 3600, OffsetTo: 3 * 3600, RRule: "FREQ=YEARLY;BYMONTH=3;BYDAY=-1SU"},
+		{Name: "EET", Start: time.Date(1970, 10, 25, 4, 0, 0, 0, time.UTC), OffsetFrom: 3 * 3600, OffsetTo: 2 * 3600, RRule: "FREQ=YEARLY;BYMONTH=10;BYDAY=-1SU"},
+	}
+	loc, err := CompileTimezone("Europe/Sofia", observances)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	sofia, _ := time.LoadLocation("Europe/Sofia")
+
+	for _, want := range []time.Time{
+		time.Date(2014, 7, 14, 10, 0, 0, 0, sofia),
+		time.Date(2014, 1, 14, 10, 0, 0, 0, sofia),
+		time.Date(2021, 3, 28, 0, 59, 0, 0, time.UTC),
+		time.Date(2021, 3, 28, 1, 0, 0, 0, time.UTC),
+		time.Date(2021, 10, 31, 0, 59, 0, 0, time.UTC),
+		time.Date(2021, 10, 31, 1, 0, 0, 0, time.UTC),
+	} {
+		wantName, wantOffset := want.In(sofia).Zone()
+		name, offset := want.In(loc).Zone()
+		if name != wantName || offset != wantOffset {
+			t.Errorf("Expected %s at %v, got %s %d", wantName, want.UTC(), name, offset)
+		}
+	}
+
+	if got := time.Date(2014, 7, 14, 10, 0, 0, 0, loc); !got.Equal(time.Date(2014, 7, 14, 7, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected 2014-07-14 07:00 UTC, got %v", got.UTC())
+	}
+}
+
+func TestCompileTimezoneWithoutRule(t *testing.T) {
+	observances := []Observance{
+		{Name: "CST", Start: time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), OffsetFrom: 8 * 3600, OffsetTo: 8 * 3600},
+	}
+	loc, err := CompileTimezone("Asia/Shanghai", observances)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if name, offset := time.Date(2020, 5, 1, 13, 0, 0, 0, loc).Zone(); name != "CST" || offset != 8*3600 {
+		t.Errorf("Expected CST +0800, got %s %d", name, offset)
+	}
+
+	if _, err := CompileTimezone("Empty", nil); err == nil {
+		t.Errorf("Expected an error for a timezone without observances")
+	}
+}
+
+func TestParseUTCOffset(t *testing.T) {
+	cases := []struct {
+		value string
+		want  int
+	}{
+		{"+0200", 7200},
+		{"-0500", -18000},
+		{"+053045", 19845},
+	}
+	for _, c := range cases {
+		if got, err := ParseUTCOffset(c.value); err != nil || got != c.want {
+			t.Errorf("ParseUTCOffset(%q) = %d, %v, want %d", c.value, got, err, c.want)
+		}
+	}
+	for _, value := range []string{"0200", "+2", "+02:00"} {
+		if _, err := ParseUTCOffset(value); err == nil {
+			t.Errorf("ParseUTCOffset(%q) = nil, want error", value)
+		}
+	}
+}
+
+func TestCompileTimezoneWithRDates(t *testing.T) {
+	observances := []Observance{
+		{Name: "CST", Start: time.Date(1919, 4, 12, 23, 0, 0, 0, time.UTC), OffsetFrom: 8 * 3600, OffsetTo: 8 * 3600},
+		{Name: "CDT", Daylight: true, Start: time.Date(1986, 5, 4, 2, 0, 0, 0, time.UTC), OffsetFrom: 8 * 3600, OffsetTo: 9 * 3600,
+			RDates: []time.Time{time.Date(1987, 4, 12, 2, 0, 0, 0, time.UTC)}},
+		{Name: "CST", Start: time.Date(1986, 9, 14, 2, 0, 0, 0, time.UTC), OffsetFrom: 9 * 3600, OffsetTo: 8 * 3600,
+			RDates: []time.Time{time.Date(1987, 9, 13, 2, 0, 0, 0, time.UTC)}},
+	}
+	loc, err := CompileTimezone("China", observances)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if name, offset := time.Date(1987, 6, 1, 12, 0, 0, 0, loc).Zone(); name != "CDT" || offset != 9*3600 {
+		t.Errorf("Expected CDT +0900 in June 1987, got %s %d", name, offset)
+	}
+	if name, offset := time.Date(1987, 10, 1, 12, 0, 0, 0, loc).Zone(); name != "CST" || offset != 8*3600 {
+		t.Errorf("Expected CST +0800 in October 1987, got %s %d", name, offset)
+	}
+}
+
+func TestLoadLocation(t *testing.T) {
+	loc, err := LoadLocation("W. Europe Standard Time")
+	if err != nil || loc.String() != "Europe/Berlin" {
+		t.Errorf("Expected Europe/Berlin for 'W. Europe Standard Time', got %v, %v", loc, err)
+	}
+	if loc, err = LoadLocation("Europe/Sofia"); err != nil || loc.String() != "Europe/Sofia" {
+		t.Errorf("Expected Europe/Sofia, got %v, %v", loc, err)
+	}
+	if _, err = LoadLocation("Nowhere Standard Time"); err == nil {
+		t.Errorf("Expected an error for an unknown timezone")
+	}
+
+	custom := time.FixedZone("Custom", 5400)
+	locations := Locations{"My Custom Zone": custom}
+	if loc, err = locations.Load("My Custom Zone"); err != nil || loc != custom {
+		t.Errorf("Expected the custom location, got %v, %v", loc, err)
+	}
+	if loc, err = locations.Load("Europe/Sofia"); err != nil || loc.String() != "Europe/Sofia" {
+		t.Errorf("Expected Europe/Sofia, got %v, %v", loc, err)
+	}
+	if _, err = LoadLocation("My Custom Zone"); err == nil {
+		t.Errorf("Expected an error for a custom location outside of the locations")
+	}
+
+	set, err := StrToRRuleSetInLocations("DTSTART;TZID=My Custom Zone:20200101T090000\nRRULE:FREQ=DAILY;COUNT=1\nRDATE;TZID=My Custom Zone:20200105T090000", time.UTC, locations)
+	if all := set.All(); err != nil || len(all) != 2 || !all[0].Equal(time.Date(2020, 1, 1, 7, 30, 0, 0, time.UTC)) || !all[1].Equal(time.Date(2020, 1, 5, 7, 30, 0, 0, time.UTC)) {
+		t.Errorf("Expected 2020-01-01 and 2020-01-05 07:30 UTC, got %v, %v", all, err)
+	}
+	if _, err = StrToRRuleSet("DTSTART;TZID=My Custom Zone:20200101T090000\nRRULE:FREQ=DAILY;COUNT=1"); err == nil {
+		t.Errorf("Expected an error for a custom location outside of the locations")
+	}
+
+	dates, err := StrToDates("TZID=Tokyo Standard Time:20200101T090000")
+	if err != nil || len(dates) != 1 || !dates[0].Equal(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected 2020-01-01 00:00 UTC, got %v, %v", dates, err)
+	}
+}
@@ -177,3 +177,35 @@ func after(next Next, dt time.Time, inc bool) time.Time {
 		}
 	}
 }
+
+// occurrenceOn returns the first occurrence that takes place on the day of 'day', an occurrence
+// without duration takes place on the day it starts
+func occurrenceOn(next Next, day time.Time, duration time.Duration) (time.Time, bool) {
+	y, m, d := day.Date()
+	from := time.Date(y, m, d, 0, 0, 0, 0, day.Location())
+	to := from.AddDate(0, 0, 1)
+	for {
+		v, ok := next()
+		if !ok || !v.Before(to) {
+			return time.Time{}, false
+		}
+		if duration <= 0 && !v.Before(from) || duration > 0 && v.Add(duration).After(from) {
+			return v, true
+		}
+	}
+}
+
+// occurrenceAt returns the last occurrence that is in progress at the instant 'dt', an occurrence
+// without duration is in progress at the instant it starts
+func occurrenceAt(next Next, dt time.Time, duration time.Duration) (time.Time, bool) {
+	result := time.Time{}
+	for {
+		v, ok := next()
+		if !ok || v.After(dt) {
+			return result, !result.IsZero()
+		}
+		if v.Equal(dt) || v.Add(duration).After(dt) {
+			result = v
+		}
+	}
+}
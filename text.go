@@ -0,0 +1,116 @@
+package icalendar
+
+import (
+	"strings"
+)
+
+// UnescapeText converts a TEXT value as found in an iCalendar stream into plain
+// text, the escaped characters '\\', '\;', '\,' and '\n' (or '\N') are replaced
+// (RFC 5545 section 3.3.11)
+func UnescapeText(value string) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+	b := &strings.Builder{}
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c == '\\' && i+1 < len(value) {
+			i++
+			switch value[i] {
+			case 'n', 'N':
+				b.WriteByte('\n')
+			case '\\', ';', ',':
+				b.WriteByte(value[i])
+			default:
+				// not a valid escape, keep it as it is
+				b.WriteByte(c)
+				b.WriteByte(value[i])
+			}
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// EscapeText converts plain text into a TEXT value that can be written to an
+// iCalendar stream, it is the inverse of UnescapeText
+func EscapeText(text string) string {
+	b := &strings.Builder{}
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch c {
+		case '\\', ';', ',':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case '\r':
+			if i+1 < len(text) && text[i+1] == '\n' {
+				continue
+			}
+			b.WriteString(`\n`)
+		case '\n':
+			b.WriteString(`\n`)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// splitText splits a multi-valued TEXT value on the commas that are not escaped,
+// the returned values are still escaped
+func splitText(value string) []string {
+	values := []string{}
+	start := 0
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' {
+			i++
+		} else if value[i] == ',' {
+			values = append(values, value[start:i])
+			start = i + 1
+		}
+	}
+	return append(values, value[start:])
+}
+
+// Text returns the unescaped value of a TEXT property
+func (p *Property) Text() string {
+	return UnescapeText(p.Value)
+}
+
+// TextValues returns the unescaped values of a multi-valued TEXT property like CATEGORIES
+func (p *Property) TextValues() []string {
+	values := splitText(p.Value)
+	for i, v := range values {
+		values[i] = UnescapeText(v)
+	}
+	return values
+}
+
+// SetText sets the value of a TEXT property, escaping it where needed
+func (p *Property) SetText(texts ...string) {
+	values := make([]string, len(texts))
+	for i, text := range texts {
+		values[i] = EscapeText(text)
+	}
+	p.Value = strings.Join(values, ",")
+}
+
+// PropertyText returns the unescaped value of the first TEXT property with the given name
+func (c *Component) PropertyText(name string) string {
+	p := c.GetProperty(name)
+	if p == nil {
+		return ""
+	}
+	return p.Text()
+}
+
+// PropertyTextValues returns the unescaped values of all the properties with
+// the given name, properties like CATEGORIES can occur multiple times
+func (c *Component) PropertyTextValues(name string) []string {
+	values := []string{}
+	for _, p := range c.GetProperties(name) {
+		values = append(values, p.TextValues()...)
+	}
+	return values
+}
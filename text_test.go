@@ -0,0 +1,61 @@
+package icalendar
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUnescapeText(t *testing.T) {
+	cases := []struct {
+		value string
+		want  string
+	}{
+		{`plain text`, "plain text"},
+		{`Room 1\, 2nd floor`, "Room 1, 2nd floor"},
+		{`first\nsecond\Nthird`, "first\nsecond\nthird"},
+		{`a\;b`, "a;b"},
+		{`C:\\temp`, `C:\temp`},
+		{`not \x an escape`, `not \x an escape`},
+		{`trailing\`, `trailing\`},
+	}
+	for _, c := range cases {
+		if got := UnescapeText(c.value); got != c.want {
+			t.Errorf("UnescapeText(%q) = %q, want %q", c.value, got, c.want)
+		}
+	}
+}
+
+func TestEscapeText(t *testing.T) {
+	text := "Meeting; agenda, notes\r\nC:\\temp\nend"
+	want := `Meeting\; agenda\, notes\nC:\\temp\nend`
+	if got := EscapeText(text); got != want {
+		t.Errorf("EscapeText(%q) = %q, want %q", text, got, want)
+	}
+	if got := UnescapeText(EscapeText("a,b;c\\d\ne")); got != "a,b;c\\d\ne" {
+		t.Errorf("Expected EscapeText and UnescapeText to round-trip, got %q", got)
+	}
+}
+
+func TestPropertyTextValues(t *testing.T) {
+	content := "BEGIN:VEVENT\r\nCATEGORIES:Work\\, urgent,Meetings\r\nCATEGORIES:Family\r\nLOCATION:Room 1\\, 2nd floor\r\nEND:VEVENT\r\n"
+	components, err := DecodeComponents(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	event := components[0]
+
+	want := []string{"Work, urgent", "Meetings", "Family"}
+	if got := event.PropertyTextValues("CATEGORIES"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected categories %v, got %v", want, got)
+	}
+	if got := event.PropertyText("LOCATION"); got != "Room 1, 2nd floor" {
+		t.Errorf("Expected location 'Room 1, 2nd floor', got '%s'", got)
+	}
+
+	p := &Property{Name: "CATEGORIES"}
+	p.SetText("Work, urgent", "Meetings")
+	if p.Value != `Work\, urgent,Meetings` {
+		t.Errorf("Expected escaped value, got '%s'", p.Value)
+	}
+}
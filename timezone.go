@@ -0,0 +1,83 @@
+package icalendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jurgen-kluft/go-icloud-calendar/rrule"
+)
+
+// parseTimezones compiles the VTIMEZONE components of the calendar into locations
+// that are used to resolve the TZID parameters of date-time values
+func (p *parser) parseTimezones(calInfo *Component) {
+	p.locations = map[string]*time.Location{}
+	for _, timezoneData := range calInfo.GetComponents("VTIMEZONE") {
+		tzid := timezoneData.PropertyValue("TZID")
+		if tzid == "" {
+			p.errorsOccured = append(p.errorsOccured, fmt.Errorf("VTIMEZONE has no TZID"))
+			continue
+		}
+		loc, err := compileTimezone(tzid, timezoneData)
+		if err != nil {
+			p.errorsOccured = append(p.errorsOccured, err)
+			continue
+		}
+		p.locations[tzid] = loc
+	}
+}
+
+// location returns the location for a TZID, the VTIMEZONE definitions of the
+// calendar take precedence over the IANA database and the Windows timezone names
+func (p *parser) location(tzid string) (*time.Location, error) {
+	if loc, ok := p.locations[tzid]; ok {
+		return loc, nil
+	}
+	loc, err := rrule.LoadLocation(tzid)
+	if err != nil {
+		return nil, fmt.Errorf("Unknown timezone '%s'", tzid)
+	}
+	return loc, nil
+}
+
+// compileTimezone builds a location from the STANDARD and DAYLIGHT observances of a VTIMEZONE
+func compileTimezone(tzid string, timezoneData *Component) (*time.Location, error) {
+	observances := []rrule.Observance{}
+	for _, c := range timezoneData.Components {
+		if c.Name != "STANDARD" && c.Name != "DAYLIGHT" {
+			continue
+		}
+		observance, err := parseObservance(c)
+		if err != nil {
+			return nil, fmt.Errorf("Timezone '%s' has an invalid %s: %s", tzid, c.Name, err)
+		}
+		observances = append(observances, observance)
+	}
+	return rrule.CompileTimezone(tzid, observances)
+}
+
+func parseObservance(observanceData *Component) (rrule.Observance, error) {
+	o := rrule.Observance{Name: observanceData.PropertyText("TZNAME"), Daylight: observanceData.Name == "DAYLIGHT"}
+
+	var err error
+	if o.Start, err = time.Parse(IcsFormatLocal, observanceData.PropertyValue("DTSTART")); err != nil {
+		return o, err
+	}
+	if o.OffsetFrom, err = rrule.ParseUTCOffset(observanceData.PropertyValue("TZOFFSETFROM")); err != nil {
+		return o, err
+	}
+	if o.OffsetTo, err = rrule.ParseUTCOffset(observanceData.PropertyValue("TZOFFSETTO")); err != nil {
+		return o, err
+	}
+	o.RRule = observanceData.PropertyValue("RRULE")
+	for _, prop := range observanceData.GetProperties("RDATE") {
+		for _, value := range strings.Split(prop.Value, ",") {
+			rdate, err := time.Parse(IcsFormatLocal, value)
+			if err != nil {
+				return o, err
+			}
+			o.RDates = append(o.RDates, rdate)
+		}
+	}
+	return o, nil
+}
@@ -0,0 +1,186 @@
+package icalendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jurgen-kluft/go-icloud-calendar/rrule"
+)
+
+func parseTestCalendar(t *testing.T, content string) *Calendar {
+	p := createParser(nil)
+	calendar := newCalendar("")
+	calendar.parser = p
+	p.parseContent(calendar, content)
+	for _, err := range p.getErrors() {
+		t.Errorf("Unexpected error %s", err)
+	}
+	return calendar
+}
+
+func TestEventTimesHonorTZID(t *testing.T) {
+	content := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"X-WR-TIMEZONE:America/New_York",
+		"BEGIN:VTIMEZONE",
+		"TZID:Custom Central",
+		"BEGIN:STANDARD",
+		"DTSTART:19701101T020000",
+		"TZOFFSETFROM:-0500",
+		"TZOFFSETTO:-0600",
+		"TZNAME:CST",
+		"RRULE:FREQ=YEARLY;BYMONTH=11;BYDAY=1SU",
+		"END:STANDARD",
+		"BEGIN:DAYLIGHT",
+		"DTSTART:19700308T020000",
+		"TZOFFSETFROM:-0600",
+		"TZOFFSETTO:-0500",
+		"TZNAME:CDT",
+		"RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=2SU",
+		"END:DAYLIGHT",
+		"END:VTIMEZONE",
+		"BEGIN:VEVENT",
+		"UID:zoned",
+		"DTSTART;TZID=Custom Central:20210705T090000",
+		"DTEND;TZID=Europe/Amsterdam:20210705T170000",
+		"RECURRENCE-ID;TZID=Custom Central:20210705T090000",
+		"EXDATE;TZID=Custom Central:20210712T090000,20210719T090000",
+		"RDATE:20210801T120000Z/PT1H",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:floating",
+		"DTSTART:20210705T090000",
+		"DTEND:20210705T100000Z",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+	calendar := parseTestCalendar(t, content)
+
+	ievent, _ := calendar.GetEventIndexByImportedID("zoned")
+	event, _ := calendar.GetEventByIndex(ievent)
+	if want := time.Date(2021, 7, 5, 14, 0, 0, 0, time.UTC); !event.Start.Equal(want) {
+		t.Errorf("Expected start %s, found %s", want, event.Start.UTC())
+	}
+	if want := time.Date(2021, 7, 5, 15, 0, 0, 0, time.UTC); !event.End.Equal(want) {
+		t.Errorf("Expected end %s, found %s", want, event.End.UTC())
+	}
+	if !event.RecurrenceID.Equal(event.Start) {
+		t.Errorf("Expected recurrence id %s, found %s", event.Start, event.RecurrenceID)
+	}
+	if len(event.ExDates) != 2 || !event.ExDates[1].Equal(time.Date(2021, 7, 19, 14, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected 2 exdates ending at 2021-07-19 14:00 UTC, found %v", event.ExDates)
+	}
+	if len(event.RDates) != 1 || !event.RDates[0].Equal(time.Date(2021, 8, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected rdate 2021-08-01 12:00 UTC, found %v", event.RDates)
+	}
+
+	ievent, _ = calendar.GetEventIndexByImportedID("floating")
+	event, _ = calendar.GetEventByIndex(ievent)
+	newYork, _ := time.LoadLocation("America/New_York")
+	if want := time.Date(2021, 7, 5, 9, 0, 0, 0, newYork); !event.Start.Equal(want) {
+		t.Errorf("Expected floating start %s, found %s", want, event.Start)
+	}
+	if want := time.Date(2021, 7, 5, 10, 0, 0, 0, time.UTC); !event.End.Equal(want) {
+		t.Errorf("Expected UTC end %s, found %s", want, event.End)
+	}
+
+	// the rules resolve the TZID of the VTIMEZONE with the locations of the calendar
+	set, err := rrule.StrToRRuleSetInLocations("DTSTART;TZID=Custom Central:20210705T090000\nRRULE:FREQ=DAILY;COUNT=1", time.UTC, calendar.GetLocations())
+	if err != nil {
+		t.Fatalf("Expected the rule to resolve the VTIMEZONE, got %s", err)
+	}
+	if all := set.All(); len(all) != 1 || !all[0].Equal(time.Date(2021, 7, 5, 14, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected 2021-07-05 14:00 UTC, found %v", all)
+	}
+}
+
+func TestEventTimeUnknownTZID(t *testing.T) {
+	content := "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART;TZID=Nowhere/Special:20210705T090000\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+	p := createParser(nil)
+	p.parseContent(newCalendar(""), content)
+	if len(p.getErrors()) != 1 {
+		t.Errorf("Expected 1 error for an unknown TZID, found %d", len(p.getErrors()))
+	}
+}
+
+func TestCalendarWindowsTimezones(t *testing.T) {
+	content := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"X-WR-TIMEZONE:Pacific Standard Time",
+		"BEGIN:VTIMEZONE",
+		"TZID:Custom Amsterdam",
+		"BEGIN:STANDARD",
+		"DTSTART:16010101T030000",
+		"TZOFFSETFROM:+0200",
+		"TZOFFSETTO:+0100",
+		"RRULE:FREQ=YEARLY;INTERVAL=1;BYDAY=-1SU;BYMONTH=10",
+		"END:STANDARD",
+		"BEGIN:DAYLIGHT",
+		"DTSTART:16010101T020000",
+		"TZOFFSETFROM:+0100",
+		"TZOFFSETTO:+0200",
+		"RRULE:FREQ=YEARLY;INTERVAL=1;BYDAY=-1SU;BYMONTH=3",
+		"END:DAYLIGHT",
+		"END:VTIMEZONE",
+		"BEGIN:VEVENT",
+		"UID:custom",
+		"DTSTART;TZID=Custom Amsterdam:20210705T090000",
+		"DTEND;TZID=\"W. Europe Standard Time\":20211105T090000",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+	calendar := parseTestCalendar(t, content)
+
+	if calendar.Timezone.String() != "America/Los_Angeles" {
+		t.Errorf("Expected calendar timezone America/Los_Angeles, got %s", calendar.Timezone)
+	}
+
+	ievent, _ := calendar.GetEventIndexByImportedID("custom")
+	event, _ := calendar.GetEventByIndex(ievent)
+	if want := time.Date(2021, 7, 5, 7, 0, 0, 0, time.UTC); !event.Start.Equal(want) {
+		t.Errorf("Expected start %s, found %s", want, event.Start.UTC())
+	}
+	if want := time.Date(2021, 11, 5, 8, 0, 0, 0, time.UTC); !event.End.Equal(want) {
+		t.Errorf("Expected end %s, found %s", want, event.End.UTC())
+	}
+}
+
+func TestRecurringEventQueriedFromOtherZone(t *testing.T) {
+	content := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:morning",
+		"DTSTART;TZID=America/New_York:20210705T090000",
+		"DTEND;TZID=America/New_York:20210705T100000",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:evening",
+		"DTSTART;TZID=America/New_York:20210705T210000",
+		"DTEND;TZID=America/New_York:20210705T220000",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+	calendar := parseTestCalendar(t, content)
+
+	tests := []struct {
+		date time.Time
+		uid  string
+	}{
+		{time.Date(2021, 7, 12, 13, 30, 0, 0, time.UTC), "morning"},
+		{time.Date(2021, 7, 13, 1, 30, 0, 0, time.UTC), "evening"},
+		{time.Date(2021, 7, 12, 21, 30, 0, 0, time.UTC), ""},
+	}
+	for _, test := range tests {
+		events := calendar.GetEventsFor(test.date)
+		uid := ""
+		if len(events) == 1 {
+			uid = events[0].ImportedID
+		}
+		if len(events) > 1 || uid != test.uid {
+			t.Errorf("Expected '%s' at %s, got %d events", test.uid, test.date, len(events))
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package icalendar
+
+import (
+	"crypto/md5"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jurgen-kluft/go-icloud-calendar/rrule"
+)
+
+// Todo holds all information for a Calendar task (VTODO), e.g. an iCloud Reminder
+type Todo struct {
+	Start           time.Time
+	StartKind       TimeKind
+	Due             time.Time
+	DueKind         TimeKind
+	Completed       time.Time
+	PercentComplete int
+	// Priority is 1 for the highest and 9 for the lowest priority, 0 when it is undefined
+	Priority    int
+	Created     time.Time
+	Modified    time.Time
+	ImportedID  string
+	Status      string
+	Summary     string
+	Description string
+	Categories  []string
+	// RelatedTo holds the UIDs of the related tasks, e.g. the parent of a sub-task
+	RelatedTo []string
+	Rrule     string
+	Class     string
+	ID        string
+	Sequence  int
+	Owner     *Calendar
+	Component *Component
+}
+
+// Todos is an array of Todo
+type Todos []*Todo
+
+// NewTodo will create a new instance of Todo
+func NewTodo() *Todo {
+	t := &Todo{}
+	t.RelatedTo = []string{}
+	return t
+}
+
+// GenerateUUID generates an unique id for the task
+func (t *Todo) GenerateUUID() string {
+	var toBeHashed string
+	if t.ImportedID != "" {
+		toBeHashed = fmt.Sprintf("%s%s%s", t.Start, t.Due, t.ImportedID)
+	} else {
+		toBeHashed = fmt.Sprintf("%s%s%d", t.Start, t.Due, time.Now().UnixNano())
+	}
+	return fmt.Sprintf("%x", md5.Sum(stringToByte(toBeHashed)))
+}
+
+// IsCompleted returns true when the task is done, it has the status COMPLETED or a COMPLETED time
+func (t *Todo) IsCompleted() bool {
+	return t.Status == "COMPLETED" || !t.Completed.IsZero()
+}
+
+// IsOverdue returns true when the task is neither done nor cancelled and its due time has
+// passed at the instant, a task that is due on a date is overdue after that day
+func (t *Todo) IsOverdue(instant time.Time) bool {
+	if t.Due.IsZero() || t.IsCompleted() || t.Status == "CANCELLED" {
+		return false
+	}
+	if t.DueKind == DateValue {
+		return !instant.Before(t.Due.AddDate(0, 0, 1))
+	}
+	return instant.After(t.Due)
+}
+
+func (t *Todo) String() string {
+	due := "NA"
+	if !t.Due.IsZero() {
+		due = t.Due.Format(YmdHis)
+	}
+	return fmt.Sprintf("Todo(%s) due %s about %s (%d%% complete, uuid:%s)", t.Status, due, t.Summary, t.PercentComplete, t.ImportedID)
+}
+
+// InsertTodo adds a task to the calendar
+func (c *Calendar) InsertTodo(todo *Todo) error {
+	// reference to the calendar
+	if todo.Owner == nil || todo.Owner != c {
+		todo.Owner = c
+	}
+
+	todoRef := Index(len(c.Todos))
+	c.Todos = append(c.Todos, todo)
+
+	// faster search by id
+	c.TodosByID[todo.ID] = todoRef
+	if todo.ImportedID != "" {
+		c.TodosByImportedID[todo.ImportedID] = todoRef
+	}
+
+	// faster search by the date the task is due
+	if !todo.Due.IsZero() {
+		date := c.dateKey(todo.Due)
+		c.TodosByDueDate[date] = append(c.TodosByDueDate[date], todoRef)
+	}
+
+	if todo.Rrule == "" {
+		return nil
+	}
+	set, err := newTodoSet(todo)
+	if err != nil {
+		return fmt.Errorf("rule %s has error %s for todo %s", todo.Rrule, err.Error(), todo.String())
+	}
+	c.RecurringTodos = append(c.RecurringTodos, todoRef)
+	c.RecurringTodoRules = append(c.RecurringTodoRules, set)
+	return nil
+}
+
+// newTodoSet creates the recurrence set of a recurring task, the rule starts at the start of
+// the task or at its due time when it has no start
+func newTodoSet(todo *Todo) (*rrule.Set, error) {
+	dtstart := todo.Start
+	if dtstart.IsZero() {
+		dtstart = todo.Due
+	}
+	if dtstart.IsZero() {
+		return nil, fmt.Errorf("a recurring task needs DTSTART or DUE")
+	}
+	option, err := rrule.StrToROptionInLocation(todo.Rrule, dtstart.Location())
+	if err != nil {
+		return nil, err
+	}
+	option.Dtstart = dtstart
+	rule, err := rrule.NewRRule(*option)
+	if err != nil {
+		return nil, err
+	}
+	set := &rrule.Set{}
+	set.DTStart(dtstart)
+	set.RRule(rule)
+	return set, nil
+}
+
+// dateKey returns the key of the day of t in the timezone of the calendar
+func (c *Calendar) dateKey(t time.Time) string {
+	t = t.In(c.Timezone)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, c.Timezone).Format(YmdHis)
+}
+
+// GetTodoByIndex get task by index
+func (c *Calendar) GetTodoByIndex(t Index) (*Todo, error) {
+	i := int(t)
+	if (i >= 0) && (i < len(c.Todos)) {
+		return c.Todos[i], nil
+	}
+	return nil, fmt.Errorf("There is no todo for index %d", i)
+}
+
+// GetTodoIndexByID get task by id
+func (c *Calendar) GetTodoIndexByID(todoID string) (Index, error) {
+	todo, ok := c.TodosByID[todoID]
+	if ok {
+		return todo, nil
+	}
+	return Index(-1), fmt.Errorf("There is no todo with id %s", todoID)
+}
+
+// GetTodoIndexByImportedID get task by imported id
+func (c *Calendar) GetTodoIndexByImportedID(todoID string) (Index, error) {
+	todo, ok := c.TodosByImportedID[todoID]
+	if ok {
+		return todo, nil
+	}
+	return Index(-1), fmt.Errorf("There is no todo with id %s", todoID)
+}
+
+// GetTodosDueOn returns the tasks that are due on the day of the date in the timezone of the
+// calendar, a recurring task is returned when one of its instances is due on the day
+func (c *Calendar) GetTodosDueOn(date time.Time) Todos {
+	date = date.In(c.Timezone)
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, c.Timezone)
+	todos := Todos{}
+	seen := map[Index]bool{}
+	for _, i := range c.TodosByDueDate[day.Format(YmdHis)] {
+		seen[i] = true
+		todos = append(todos, c.Todos[i])
+	}
+
+	for i, set := range c.RecurringTodoRules {
+		todoRef := c.RecurringTodos[i]
+		todo := c.Todos[todoRef]
+		if seen[todoRef] || todo.Due.IsZero() {
+			continue
+		}
+		// the instances are due as long after their start as the task itself
+		offset := todo.Due.Sub(set.GetDTStart())
+		if len(set.Between(day.Add(-offset), day.AddDate(0, 0, 1).Add(-offset-time.Second), true)) != 0 {
+			todos = append(todos, todo)
+		}
+	}
+	return todos
+}
+
+// GetOverdueTodos returns the tasks that are overdue at the instant, the task that is due
+// first comes first
+func (c *Calendar) GetOverdueTodos(instant time.Time) Todos {
+	todos := Todos{}
+	for _, todo := range c.Todos {
+		if todo.IsOverdue(instant) {
+			todos = append(todos, todo)
+		}
+	}
+	sort.SliceStable(todos, func(i, j int) bool {
+		return todos[i].Due.Before(todos[j].Due)
+	})
+	return todos
+}
+
+// GetCompletedTodos returns the tasks that are done, the task that was completed first comes first
+func (c *Calendar) GetCompletedTodos() Todos {
+	todos := Todos{}
+	for _, todo := range c.Todos {
+		if todo.IsCompleted() {
+			todos = append(todos, todo)
+		}
+	}
+	sort.SliceStable(todos, func(i, j int) bool {
+		return todos[i].Completed.Before(todos[j].Completed)
+	})
+	return todos
+}
+
+// GetRelatedTodos returns the tasks that are related to the task with the imported id
+// (RELATED-TO), e.g. the sub-tasks of a task
+func (c *Calendar) GetRelatedTodos(importedID string) Todos {
+	todos := Todos{}
+	for _, todo := range c.Todos {
+		for _, uid := range todo.RelatedTo {
+			if uid == importedID {
+				todos = append(todos, todo)
+				break
+			}
+		}
+	}
+	return todos
+}
@@ -0,0 +1,95 @@
+package icalendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTodos(t *testing.T) {
+	content := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"X-WR-TIMEZONE:Europe/Amsterdam",
+		"BEGIN:VEVENT",
+		"UID:meeting",
+		"DTSTART:20260708T110000Z",
+		"DTEND:20260708T120000Z",
+		"END:VEVENT",
+		"BEGIN:VTODO",
+		"UID:report",
+		"SUMMARY:Write report",
+		"DUE;TZID=Europe/Amsterdam:20260710T170000",
+		"PRIORITY:1",
+		"PERCENT-COMPLETE:40",
+		"STATUS:IN-PROCESS",
+		"END:VTODO",
+		"BEGIN:VTODO",
+		"UID:figures",
+		"SUMMARY:Add figures",
+		"DUE;VALUE=DATE:20260709",
+		"RELATED-TO;RELTYPE=PARENT:report",
+		"STATUS:NEEDS-ACTION",
+		"END:VTODO",
+		"BEGIN:VTODO",
+		"UID:draft",
+		"SUMMARY:Draft outline",
+		"DUE;VALUE=DATE:20260706",
+		"COMPLETED:20260705T183000Z",
+		"PERCENT-COMPLETE:100",
+		"STATUS:COMPLETED",
+		"RELATED-TO:report",
+		"END:VTODO",
+		"BEGIN:VTODO",
+		"UID:plants",
+		"SUMMARY:Water the plants",
+		"DTSTART;TZID=Europe/Amsterdam:20260701T090000",
+		"DUE;TZID=Europe/Amsterdam:20260701T100000",
+		"RRULE:FREQ=WEEKLY;BYDAY=WE",
+		"END:VTODO",
+		"END:VCALENDAR",
+	}, "\r\n")
+	calendar := parseTestCalendar(t, content)
+	amsterdam, _ := time.LoadLocation("Europe/Amsterdam")
+
+	if len(calendar.Events) != 1 || len(calendar.Todos) != 4 {
+		t.Fatalf("Expected 1 event and 4 todos, got %d and %d", len(calendar.Events), len(calendar.Todos))
+	}
+	i, err := calendar.GetTodoIndexByImportedID("report")
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, _ := calendar.GetTodoByIndex(i)
+	if !report.Due.Equal(time.Date(2026, 7, 10, 17, 0, 0, 0, amsterdam)) || report.Priority != 1 || report.PercentComplete != 40 || report.Status != "IN-PROCESS" {
+		t.Errorf("Expected the report to be due on 10 July at 17:00 with priority 1 and 40%% complete, got %s", report)
+	}
+	if j, err := calendar.GetTodoIndexByID(report.ID); err != nil || j != i {
+		t.Errorf("Expected todo %d by id, got %d (%v)", i, j, err)
+	}
+
+	summaries := func(todos Todos) string {
+		names := []string{}
+		for _, todo := range todos {
+			names = append(names, todo.Summary)
+		}
+		return strings.Join(names, ", ")
+	}
+	tests := []struct {
+		name  string
+		todos Todos
+		want  string
+	}{
+		{"related", calendar.GetRelatedTodos("report"), "Add figures, Draft outline"},
+		{"completed", calendar.GetCompletedTodos(), "Draft outline"},
+		{"overdue on the due date", calendar.GetOverdueTodos(time.Date(2026, 7, 9, 23, 0, 0, 0, amsterdam)), "Water the plants"},
+		{"overdue after the due date", calendar.GetOverdueTodos(time.Date(2026, 7, 10, 18, 0, 0, 0, amsterdam)), "Water the plants, Add figures, Write report"},
+		{"due on", calendar.GetTodosDueOn(time.Date(2026, 7, 10, 12, 0, 0, 0, amsterdam)), "Write report"},
+		{"due on a date", calendar.GetTodosDueOn(time.Date(2026, 7, 9, 0, 0, 0, 0, amsterdam)), "Add figures"},
+		{"due on a repeated instance", calendar.GetTodosDueOn(time.Date(2026, 7, 15, 0, 0, 0, 0, amsterdam)), "Water the plants"},
+		{"due on no instance", calendar.GetTodosDueOn(time.Date(2026, 7, 16, 0, 0, 0, 0, amsterdam)), ""},
+	}
+	for _, test := range tests {
+		if value := summaries(test.todos); value != test.want {
+			t.Errorf("%s: Expected %q, got %q", test.name, test.want, value)
+		}
+	}
+}